@@ -0,0 +1,8 @@
+data "apollostudio_sub_graph" "example" {
+  name      = "sub-graph-name"
+  graph_ref = "my-graph-name@main"
+}
+
+output "sub_graph_url" {
+  value = data.apollostudio_sub_graph.example.url
+}
@@ -0,0 +1,7 @@
+data "apollostudio_sub_graphs" "example" {
+  graph_ref = "my-graph-name@main"
+}
+
+output "sub_graph_urls" {
+  value = { for sg in data.apollostudio_sub_graphs.example.sub_graphs : sg.name => sg.url }
+}
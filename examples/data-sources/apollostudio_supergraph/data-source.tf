@@ -0,0 +1,8 @@
+data "apollostudio_supergraph" "example" {
+  graph_ref = "my-graph-name@main"
+}
+
+resource "local_file" "supergraph" {
+  filename = "${path.module}/supergraph.graphql"
+  content  = data.apollostudio_supergraph.example.schema
+}
@@ -0,0 +1,2 @@
+# Graphs can be imported by their ID
+terraform import apollostudio_graph.example my-graph
@@ -0,0 +1,6 @@
+resource "apollostudio_graph" "example" {
+  graph_id        = "my-graph"
+  title           = "My Graph"
+  description     = "The supergraph of my organization"
+  organization_id = "my-organization"
+}
@@ -0,0 +1,3 @@
+# API keys can be imported by graph ID and key ID, the token is not available
+# after importing
+terraform import apollostudio_graph_api_key.router my-graph/key-id
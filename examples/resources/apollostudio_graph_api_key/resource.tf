@@ -0,0 +1,10 @@
+resource "apollostudio_graph_api_key" "router" {
+  graph_id = "my-graph"
+  name     = "router"
+  role     = "CONTRIBUTOR"
+}
+
+output "router_api_key" {
+  value     = apollostudio_graph_api_key.router.token
+  sensitive = true
+}
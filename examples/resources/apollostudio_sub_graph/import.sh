@@ -0,0 +1,5 @@
+# Sub graphs can be imported by name, using the provider graph ref
+terraform import apollostudio_sub_graph.example sub-graph-name
+
+# or by graph ref and name
+terraform import apollostudio_sub_graph.example my-graph-name@main/sub-graph-name
@@ -0,0 +1,2 @@
+# Variants can be imported by their graph ref
+terraform import apollostudio_variant.staging my-graph@staging
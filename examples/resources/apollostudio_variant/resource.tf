@@ -0,0 +1,10 @@
+resource "apollostudio_variant" "staging" {
+  graph_id  = "my-graph"
+  name      = "staging"
+  url       = "https://staging.example.com/graphql"
+  is_public = false
+
+  default_headers = {
+    "apollographql-client-name" = "explorer"
+  }
+}
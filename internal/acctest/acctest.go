@@ -10,15 +10,29 @@ import (
 func ConfigureProvider(p tfprotov5.ProviderServer) error {
 	testType := tftypes.Object{
 		AttributeTypes: map[string]tftypes.Type{
-			"api_key":   tftypes.String,
-			"graph_ref": tftypes.String,
+			"api_key":                 tftypes.String,
+			"graph_ref":               tftypes.String,
+			"endpoint":                tftypes.String,
+			"max_retries":             tftypes.Number,
+			"min_backoff":             tftypes.String,
+			"max_backoff":             tftypes.String,
+			"request_timeout":         tftypes.String,
+			"default_timeout":         tftypes.String,
+			"max_requests_per_second": tftypes.Number,
 		},
 	}
 
 	testValue := tftypes.NewValue(
 		testType, map[string]tftypes.Value{
-			"api_key":   tftypes.NewValue(tftypes.String, os.Getenv("APOLLO_API_KEY")),
-			"graph_ref": tftypes.NewValue(tftypes.String, os.Getenv("APOLLO_GRAPH_REF")),
+			"api_key":                 tftypes.NewValue(tftypes.String, os.Getenv("APOLLO_API_KEY")),
+			"graph_ref":               tftypes.NewValue(tftypes.String, os.Getenv("APOLLO_GRAPH_REF")),
+			"endpoint":                tftypes.NewValue(tftypes.String, nil),
+			"max_retries":             tftypes.NewValue(tftypes.Number, nil),
+			"min_backoff":             tftypes.NewValue(tftypes.String, nil),
+			"max_backoff":             tftypes.NewValue(tftypes.String, nil),
+			"request_timeout":         tftypes.NewValue(tftypes.String, nil),
+			"default_timeout":         tftypes.NewValue(tftypes.String, nil),
+			"max_requests_per_second": tftypes.NewValue(tftypes.Number, nil),
 		},
 	)
 
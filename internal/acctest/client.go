@@ -1,15 +1,23 @@
 package acctest
 
 import (
-	"github.com/labd/apollostudio-go-sdk/apollostudio"
+	"net/http"
 	"os"
+
+	"github.com/labd/apollostudio-go-sdk/apollostudio"
+	"github.com/labd/terraform-provider-apollostudio/internal/platform"
 )
 
 func GetClient() (*apollostudio.Client, error) {
 	key := os.Getenv("APOLLO_API_KEY")
 	ref := os.Getenv("APOLLO_GRAPH_REF")
 
-	client, err := apollostudio.NewClient(key, ref)
+	var opts []apollostudio.ClientOpt
+	if endpoint := os.Getenv("APOLLO_ENDPOINT"); endpoint != "" {
+		opts = append(opts, apollostudio.WithUrl(endpoint))
+	}
+
+	client, err := apollostudio.NewClient(key, ref, opts...)
 
 	if err != nil {
 		return nil, err
@@ -17,3 +25,12 @@ func GetClient() (*apollostudio.Client, error) {
 
 	return client, nil
 }
+
+func GetPlatformClient() *platform.Client {
+	endpoint := os.Getenv("APOLLO_ENDPOINT")
+	if endpoint == "" {
+		endpoint = platform.DefaultEndpoint
+	}
+
+	return platform.NewClient(endpoint, os.Getenv("APOLLO_API_KEY"), http.DefaultClient)
+}
@@ -0,0 +1,31 @@
+package acctest
+
+import (
+	"os"
+	"strings"
+
+	"github.com/labd/terraform-provider-apollostudio/internal/acctest/mockserver"
+)
+
+// StartMockServer starts the in-process Apollo Platform API mock when
+// APOLLO_MOCK_SERVER is set and points APOLLO_ENDPOINT at it. The graph of
+// APOLLO_GRAPH_REF is registered on the mock, and APOLLO_ORGANIZATION_ID
+// defaults to its organization. It returns nil when the tests should run
+// against the real API.
+func StartMockServer() *mockserver.Server {
+	if os.Getenv("APOLLO_MOCK_SERVER") == "" {
+		return nil
+	}
+
+	server := mockserver.New()
+	if graphID, _, ok := strings.Cut(os.Getenv("APOLLO_GRAPH_REF"), "@"); ok {
+		server.AddGraph(graphID)
+	}
+
+	if os.Getenv("APOLLO_ORGANIZATION_ID") == "" {
+		_ = os.Setenv("APOLLO_ORGANIZATION_ID", "unittest")
+	}
+
+	_ = os.Setenv("APOLLO_ENDPOINT", server.URL)
+	return server
+}
@@ -0,0 +1,285 @@
+package mockserver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// federationPrelude declares the federation directives so subgraph schemas
+// can be validated on their own.
+var federationPrelude = &ast.Source{
+	Name:    "federation.graphql",
+	BuiltIn: true,
+	Input: `
+scalar _Any
+scalar _FieldSet
+scalar FieldSet
+scalar link__Import
+enum link__Purpose { SECURITY EXECUTION }
+
+directive @key(fields: _FieldSet!, resolvable: Boolean = true) repeatable on OBJECT | INTERFACE
+directive @external(reason: String) on OBJECT | FIELD_DEFINITION
+directive @requires(fields: _FieldSet!) on FIELD_DEFINITION
+directive @provides(fields: _FieldSet!) on FIELD_DEFINITION
+directive @extends on OBJECT | INTERFACE
+directive @shareable repeatable on OBJECT | FIELD_DEFINITION
+directive @inaccessible on FIELD_DEFINITION | OBJECT | INTERFACE | UNION | ARGUMENT_DEFINITION | SCALAR | ENUM | ENUM_VALUE | INPUT_OBJECT | INPUT_FIELD_DEFINITION
+directive @override(from: String!, label: String) on FIELD_DEFINITION
+directive @tag(name: String!) repeatable on FIELD_DEFINITION | OBJECT | INTERFACE | UNION | ARGUMENT_DEFINITION | SCALAR | ENUM | ENUM_VALUE | INPUT_OBJECT | INPUT_FIELD_DEFINITION
+directive @link(url: String!, as: String, for: link__Purpose, import: [link__Import]) repeatable on SCHEMA
+directive @composeDirective(name: String!) repeatable on SCHEMA
+directive @interfaceObject on OBJECT
+`,
+}
+
+// checkWindow is the traffic history the operation checks consider.
+const checkWindow = 7 * 24 * time.Hour
+
+type location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type compositionError struct {
+	Message   string     `json:"message"`
+	Code      string     `json:"code"`
+	Locations []location `json:"locations"`
+}
+
+type change struct {
+	Severity    string
+	Code        string
+	Category    string
+	Description string
+	// Coordinate is the schema coordinate the change applies to, for
+	// example `Query.products`.
+	Coordinate string
+	// Affected holds the operations broken by the change.
+	Affected []Operation
+}
+
+// validateSubgraph checks that the subgraph SDL is valid GraphQL.
+func validateSubgraph(name, sdl string) []compositionError {
+	_, err := gqlparser.LoadSchema(federationPrelude, &ast.Source{Name: name, Input: sdl})
+	if err == nil {
+		return nil
+	}
+
+	var gqlErr *gqlerror.Error
+	if !errors.As(err, &gqlErr) {
+		return []compositionError{{Message: err.Error(), Code: "INVALID_GRAPHQL"}}
+	}
+
+	result := compositionError{
+		Message: fmt.Sprintf("[%s] %s", name, gqlErr.Message),
+		Code:    "INVALID_GRAPHQL",
+	}
+	for _, l := range gqlErr.Locations {
+		result.Locations = append(result.Locations, location{Line: l.Line, Column: l.Column})
+	}
+	return []compositionError{result}
+}
+
+// compose validates all subgraphs and builds a supergraph document out of
+// them. The mock does not implement real composition, the supergraph is the
+// concatenation of the subgraph schemas.
+func compose(subgraphs []*subgraph) (string, []compositionError) {
+	var errs []compositionError
+	var doc strings.Builder
+
+	for _, s := range subgraphs {
+		errs = append(errs, validateSubgraph(s.name, s.sdl)...)
+		fmt.Fprintf(&doc, "# subgraph: %s\n%s\n", s.name, s.sdl)
+	}
+
+	if len(errs) > 0 {
+		return "", errs
+	}
+	return doc.String(), nil
+}
+
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// diff returns the type and field level changes between two subgraph SDLs.
+func diff(previous, proposed string) []change {
+	before := schemaFields(previous)
+	after := schemaFields(proposed)
+
+	var changes []change
+	for _, typeName := range sortedKeys(before) {
+		fields, ok := after[typeName]
+		if !ok {
+			changes = append(changes, change{
+				Severity:    "NOTICE",
+				Code:        "TYPE_REMOVED",
+				Category:    "REMOVAL",
+				Coordinate:  typeName,
+				Description: fmt.Sprintf("type `%s`: removed", typeName),
+			})
+			continue
+		}
+		for _, fieldName := range sortedKeys(before[typeName]) {
+			coordinate := typeName + "." + fieldName
+			newType, ok := fields[fieldName]
+			switch {
+			case !ok:
+				changes = append(changes, change{
+					Severity:    "NOTICE",
+					Code:        "FIELD_REMOVED",
+					Category:    "REMOVAL",
+					Coordinate:  coordinate,
+					Description: fmt.Sprintf("type `%s`: field `%s` removed", typeName, fieldName),
+				})
+			case newType != before[typeName][fieldName]:
+				changes = append(changes, change{
+					Severity:   "NOTICE",
+					Code:       "FIELD_CHANGED_TYPE",
+					Category:   "EDIT",
+					Coordinate: coordinate,
+					Description: fmt.Sprintf(
+						"type `%s`: field `%s` changed type from `%s` to `%s`",
+						typeName, fieldName, before[typeName][fieldName], newType,
+					),
+				})
+			}
+		}
+	}
+
+	for _, typeName := range sortedKeys(after) {
+		fields, ok := before[typeName]
+		if !ok {
+			changes = append(changes, change{
+				Severity:    "NOTICE",
+				Code:        "TYPE_ADDED",
+				Category:    "ADDITION",
+				Coordinate:  typeName,
+				Description: fmt.Sprintf("type `%s`: created", typeName),
+			})
+			continue
+		}
+		for _, fieldName := range sortedKeys(after[typeName]) {
+			if _, ok := fields[fieldName]; !ok {
+				changes = append(changes, change{
+					Severity:    "NOTICE",
+					Code:        "FIELD_ADDED",
+					Category:    "ADDITION",
+					Coordinate:  typeName + "." + fieldName,
+					Description: fmt.Sprintf("type `%s`: field `%s` added", typeName, fieldName),
+				})
+			}
+		}
+	}
+
+	return changes
+}
+
+// filterOperations returns the operations the historic parameters of a check
+// select: operations seen since from that reach the request count threshold
+// and are not sent by an excluded client or excluded by name.
+func filterOperations(operations []Operation, params map[string]any, from time.Time) []Operation {
+	excludedClients := map[string]bool{}
+	for _, c := range objectListArg(params, "excludedClients") {
+		excludedClients[stringArg(c, "name")] = true
+	}
+	excludedOperations := map[string]bool{}
+	for _, o := range objectListArg(params, "excludedOperationNames") {
+		excludedOperations[stringArg(o, "name")] = true
+	}
+	threshold := intArg(params, "queryCountThreshold")
+
+	var result []Operation
+	for _, op := range operations {
+		switch {
+		case !op.LastSeen.IsZero() && op.LastSeen.Before(from):
+		case op.RequestCount < threshold:
+		case excludedClients[op.ClientName]:
+		case excludedOperations[op.Name]:
+		default:
+			result = append(result, op)
+		}
+	}
+	return result
+}
+
+// checkOperations marks the changes that break any of the operations as
+// failures. Additions never break an operation.
+func checkOperations(changes []change, operations []Operation) {
+	for i := range changes {
+		c := &changes[i]
+		if c.Category == "ADDITION" {
+			continue
+		}
+		for _, op := range operations {
+			if usesCoordinate(op, c.Coordinate) {
+				c.Affected = append(c.Affected, op)
+			}
+		}
+		if len(c.Affected) > 0 {
+			c.Severity = "FAILURE"
+		}
+	}
+}
+
+// usesCoordinate reports whether the operation selects the field, or any
+// field of the type, the coordinate points to.
+func usesCoordinate(op Operation, coordinate string) bool {
+	for _, f := range op.Fields {
+		if f == coordinate || strings.HasPrefix(f, coordinate+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaFields maps every type in the SDL to its fields and their types.
+// Type extensions are merged into the extended type.
+func schemaFields(sdl string) map[string]map[string]string {
+	result := map[string]map[string]string{}
+	if sdl == "" {
+		return result
+	}
+
+	doc, err := parser.ParseSchema(&ast.Source{Input: sdl})
+	if err != nil {
+		return result
+	}
+
+	definitions := append(ast.DefinitionList{}, doc.Definitions...)
+	definitions = append(definitions, doc.Extensions...)
+	for _, def := range definitions {
+		fields, ok := result[def.Name]
+		if !ok {
+			fields = map[string]string{}
+			result[def.Name] = fields
+		}
+		for _, f := range def.Fields {
+			fields[f.Name] = f.Type.String()
+		}
+		for _, v := range def.EnumValues {
+			fields[v.Name] = def.Name
+		}
+	}
+	return result
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,198 @@
+package mockserver
+
+import (
+	"fmt"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// object is a GraphQL object exposed by the mock server. Field values are
+// either plain values, nested objects or resolvers which receive the field
+// arguments.
+type object struct {
+	typename string
+	fields   map[string]any
+}
+
+type resolver func(args map[string]any) (any, error)
+
+// executor resolves the selection sets of a parsed operation against the
+// mock object graph. The operation is validated against the Platform API
+// schema before it is executed, so the executor does not check the requested
+// fields or arguments itself.
+type executor struct {
+	doc  *ast.QueryDocument
+	vars map[string]any
+}
+
+func (e *executor) resolve(set ast.SelectionSet, value any) (any, error) {
+	switch v := value.(type) {
+	case *object:
+		if v == nil {
+			return nil, nil
+		}
+		out := map[string]any{}
+		if err := e.resolveObject(set, v, out); err != nil {
+			return nil, err
+		}
+		return out, nil
+	case []*object:
+		list := make([]any, len(v))
+		for i, o := range v {
+			r, err := e.resolve(set, o)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = r
+		}
+		return list, nil
+	default:
+		return v, nil
+	}
+}
+
+func (e *executor) resolveObject(set ast.SelectionSet, o *object, out map[string]any) error {
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			skip, err := e.skipped(sel.Directives)
+			if err != nil {
+				return err
+			}
+			if skip {
+				continue
+			}
+
+			key := sel.Alias
+			if key == "" {
+				key = sel.Name
+			}
+
+			if sel.Name == "__typename" {
+				out[key] = o.typename
+				continue
+			}
+
+			value, ok := o.fields[sel.Name]
+			if !ok {
+				return fmt.Errorf("cannot query field %q on type %q", sel.Name, o.typename)
+			}
+
+			if r, ok := value.(resolver); ok {
+				args, err := e.arguments(sel.Arguments)
+				if err != nil {
+					return err
+				}
+				value, err = r(args)
+				if err != nil {
+					return err
+				}
+			}
+
+			result, err := e.resolve(sel.SelectionSet, value)
+			if err != nil {
+				return err
+			}
+			out[key] = result
+		case *ast.InlineFragment:
+			if sel.TypeCondition != "" && sel.TypeCondition != o.typename {
+				continue
+			}
+			if err := e.resolveObject(sel.SelectionSet, o, out); err != nil {
+				return err
+			}
+		case *ast.FragmentSpread:
+			fragment := e.doc.Fragments.ForName(sel.Name)
+			if fragment == nil {
+				return fmt.Errorf("unknown fragment %q", sel.Name)
+			}
+			if fragment.TypeCondition != o.typename {
+				continue
+			}
+			if err := e.resolveObject(fragment.SelectionSet, o, out); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *executor) arguments(list ast.ArgumentList) (map[string]any, error) {
+	args := make(map[string]any, len(list))
+	for _, arg := range list {
+		value, err := arg.Value.Value(e.vars)
+		if err != nil {
+			return nil, err
+		}
+		args[arg.Name] = value
+	}
+	return args, nil
+}
+
+// skipped reports whether a field is excluded by @skip or @include.
+func (e *executor) skipped(directives ast.DirectiveList) (bool, error) {
+	for _, d := range directives {
+		if d.Name != "skip" && d.Name != "include" {
+			continue
+		}
+		args, err := e.arguments(d.Arguments)
+		if err != nil {
+			return false, err
+		}
+		if boolArg(args, "if") == (d.Name == "skip") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func stringArg(args map[string]any, name string) string {
+	if v, ok := args[name].(string); ok {
+		return v
+	}
+	return ""
+}
+
+func optionalStringArg(args map[string]any, name string) *string {
+	if v, ok := args[name].(string); ok {
+		return &v
+	}
+	return nil
+}
+
+func boolArg(args map[string]any, name string) bool {
+	if v, ok := args[name].(bool); ok {
+		return v
+	}
+	return false
+}
+
+// intArg reads an integer argument, variables are decoded from JSON as
+// floats while literals are parsed as integers.
+func intArg(args map[string]any, name string) int {
+	switch v := args[name].(type) {
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+func objectListArg(args map[string]any, name string) []map[string]any {
+	values, _ := args[name].([]any)
+	result := make([]map[string]any, 0, len(values))
+	for _, v := range values {
+		if o, ok := v.(map[string]any); ok {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
+func objectArg(args map[string]any, name string) map[string]any {
+	if v, ok := args[name].(map[string]any); ok {
+		return v
+	}
+	return map[string]any{}
+}
@@ -0,0 +1,80 @@
+package mockserver
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+type lintDiagnostic struct {
+	Rule       string
+	Level      string
+	Coordinate string
+	Message    string
+}
+
+// lint applies a small subset of the GraphOS linter rules to the SDL. Only
+// the violations that are not present in the base SDL are returned, like the
+// linter does for schema checks.
+func lint(sdl, baseSDL string) []lintDiagnostic {
+	existing := map[string]bool{}
+	for _, d := range lintRules(baseSDL) {
+		existing[d.Rule+" "+d.Coordinate] = true
+	}
+
+	var result []lintDiagnostic
+	for _, d := range lintRules(sdl) {
+		if !existing[d.Rule+" "+d.Coordinate] {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+func lintRules(sdl string) []lintDiagnostic {
+	if sdl == "" {
+		return nil
+	}
+
+	doc, err := parser.ParseSchema(&ast.Source{Input: sdl})
+	if err != nil {
+		return nil
+	}
+
+	var result []lintDiagnostic
+	definitions := append(ast.DefinitionList{}, doc.Definitions...)
+	definitions = append(definitions, doc.Extensions...)
+	for _, def := range definitions {
+		if r := []rune(def.Name); len(r) > 0 && !unicode.IsUpper(r[0]) {
+			result = append(result, lintDiagnostic{
+				Rule:       "TYPE_NAMES_SHOULD_BE_PASCAL_CASE",
+				Level:      "WARNING",
+				Coordinate: def.Name,
+				Message:    fmt.Sprintf("Type names should use PascalCase, `%s` does not", def.Name),
+			})
+		}
+		for _, f := range def.Fields {
+			coordinate := def.Name + "." + f.Name
+			if strings.Contains(f.Name, "_") {
+				result = append(result, lintDiagnostic{
+					Rule:       "FIELD_NAMES_SHOULD_BE_CAMEL_CASE",
+					Level:      "WARNING",
+					Coordinate: coordinate,
+					Message:    fmt.Sprintf("Field names should use camelCase, `%s` does not", f.Name),
+				})
+			}
+			if d := f.Directives.ForName("deprecated"); d != nil && d.Arguments.ForName("reason") == nil {
+				result = append(result, lintDiagnostic{
+					Rule:       "DEPRECATED_DIRECTIVE_MISSING_REASON",
+					Level:      "ERROR",
+					Coordinate: coordinate,
+					Message:    fmt.Sprintf("The @deprecated directive of `%s` should have a reason", coordinate),
+				})
+			}
+		}
+	}
+	return result
+}
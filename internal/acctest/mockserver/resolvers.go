@@ -0,0 +1,727 @@
+package mockserver
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+func (s *Server) queryRoot() *object {
+	return &object{
+		typename: "Query",
+		fields: map[string]any{
+			"variant": resolver(func(args map[string]any) (any, error) {
+				ref := stringArg(args, "ref")
+				graphID, name, ok := strings.Cut(ref, "@")
+				if !ok {
+					return &object{
+						typename: "InvalidRefFormat",
+						fields: map[string]any{
+							"message": fmt.Sprintf("invalid graph ref %q", ref),
+						},
+					}, nil
+				}
+				return variantObject(graphID, s.lookupVariant(graphID, name)), nil
+			}),
+			"graph":   resolver(s.resolveGraph),
+			"service": resolver(s.resolveGraph),
+		},
+	}
+}
+
+func (s *Server) resolveGraph(args map[string]any) (any, error) {
+	g, ok := s.graphs[stringArg(args, "id")]
+	if !ok {
+		return (*object)(nil), nil
+	}
+	return graphObject(g), nil
+}
+
+func graphObject(g *graph) *object {
+	variants := []*object{}
+	for _, name := range sortedKeys(g.variants) {
+		variants = append(variants, variantObject(g.id, g.variants[name]))
+	}
+
+	apiKeys := []*object{}
+	for _, key := range g.apiKeys {
+		apiKeys = append(apiKeys, apiKeyObject(key))
+	}
+
+	return &object{
+		typename: "Graph",
+		fields: map[string]any{
+			"id":          g.id,
+			"name":        g.id,
+			"title":       g.title,
+			"description": g.description,
+			"graphType":   g.graphType,
+			"account": &object{
+				typename: "Account",
+				fields: map[string]any{
+					"id": g.accountID,
+				},
+			},
+			"variants": variants,
+			"variant": resolver(func(args map[string]any) (any, error) {
+				return variantObject(g.id, g.variants[stringArg(args, "name")]), nil
+			}),
+			"apiKeys": apiKeys,
+			"checkWorkflow": resolver(func(args map[string]any) (any, error) {
+				return checkWorkflowObject(g.checks[stringArg(args, "id")]), nil
+			}),
+		},
+	}
+}
+
+func lintResultObject(diagnostics []lintDiagnostic) *object {
+	result := make([]*object, len(diagnostics))
+	for i, d := range diagnostics {
+		result[i] = &object{
+			typename: "LintDiagnostic",
+			fields: map[string]any{
+				"rule":       d.Rule,
+				"level":      d.Level,
+				"coordinate": d.Coordinate,
+				"message":    d.Message,
+			},
+		}
+	}
+	return &object{
+		typename: "LintResult",
+		fields: map[string]any{
+			"diagnostics": result,
+		},
+	}
+}
+
+func apiKeyObject(key *apiKey) *object {
+	if key == nil {
+		return nil
+	}
+
+	return &object{
+		typename: "GraphApiKey",
+		fields: map[string]any{
+			"id":        key.id,
+			"keyName":   key.name,
+			"token":     key.token,
+			"role":      key.role,
+			"createdAt": key.createdAt,
+		},
+	}
+}
+
+func variantObject(graphID string, v *variant) *object {
+	if v == nil {
+		return nil
+	}
+
+	subgraphs := []*object{}
+	for _, name := range sortedKeys(v.subgraphs) {
+		subgraphs = append(subgraphs, subgraphObject(graphID, v.name, v.subgraphs[name]))
+	}
+
+	var latestLaunch *object
+	if len(v.launches) > 0 {
+		latestLaunch = launchObject(v.launches[len(v.launches)-1])
+	}
+
+	return &object{
+		typename: "GraphVariant",
+		fields: map[string]any{
+			"id":             graphID + "@" + v.name,
+			"name":           v.name,
+			"url":            v.url,
+			"isPublic":       v.isPublic,
+			"defaultHeaders": v.defaultHeaders,
+			"subgraphs":      subgraphs,
+			"latestLaunch":   latestLaunch,
+			"subgraph": resolver(func(args map[string]any) (any, error) {
+				return subgraphObject(graphID, v.name, v.subgraphs[stringArg(args, "name")]), nil
+			}),
+		},
+	}
+}
+
+func subgraphObject(graphID, variantName string, sg *subgraph) *object {
+	if sg == nil {
+		return nil
+	}
+
+	return &object{
+		typename: "GraphVariantSubgraph",
+		fields: map[string]any{
+			"graphID":      graphID,
+			"graphVariant": variantName,
+			"name":         sg.name,
+			"url":          sg.url,
+			"revision":     sg.revision,
+			"createdAt":    sg.createdAt,
+			"updatedAt":    sg.updatedAt,
+			"activePartialSchema": &object{
+				typename: "SubgraphSchema",
+				fields: map[string]any{
+					"sdl": sg.sdl,
+				},
+			},
+		},
+	}
+}
+
+func launchObject(l *launch) *object {
+	var result *object
+	status := "LAUNCH_COMPLETED"
+	if l.pending {
+		status = "LAUNCH_INITIATED"
+	} else if len(l.errors) > 0 {
+		status = "LAUNCH_FAILED"
+		result = &object{
+			typename: "BuildFailure",
+			fields: map[string]any{
+				"errorMessages": compositionErrorObjects(l.errors),
+			},
+		}
+	} else {
+		result = &object{
+			typename: "BuildSuccess",
+			fields: map[string]any{
+				"coreSchema": &object{
+					typename: "CoreSchema",
+					fields: map[string]any{
+						"coreDocument": l.coreDocument,
+						"coreHash":     hash(l.coreDocument),
+					},
+				},
+			},
+		}
+	}
+
+	subgraphs := make([]*object, len(l.subgraphs))
+	for i, sg := range l.subgraphs {
+		subgraphs[i] = &object{
+			typename: "BuildInputSubgraph",
+			fields: map[string]any{
+				"name": sg.name,
+				"hash": sg.hash,
+			},
+		}
+	}
+
+	return &object{
+		typename: "Launch",
+		fields: map[string]any{
+			"id":        l.id,
+			"status":    status,
+			"createdAt": l.createdAt,
+			"build": &object{
+				typename: "Build",
+				fields: map[string]any{
+					"result": result,
+					"input": &object{
+						typename: "CompositionBuildInput",
+						fields: map[string]any{
+							"subgraphs": subgraphs,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func compositionErrorObjects(errs []compositionError) []*object {
+	result := make([]*object, len(errs))
+	for i, e := range errs {
+		locations := make([]*object, len(e.Locations))
+		for j, l := range e.Locations {
+			locations[j] = &object{
+				typename: "SourceLocation",
+				fields: map[string]any{
+					"line":   l.Line,
+					"column": l.Column,
+				},
+			}
+		}
+		result[i] = &object{
+			typename: "SchemaCompositionError",
+			fields: map[string]any{
+				"message":   e.Message,
+				"code":      e.Code,
+				"locations": locations,
+			},
+		}
+	}
+	return result
+}
+
+func affectedQueryObjects(operations []Operation) []*object {
+	result := make([]*object, len(operations))
+	for i, op := range operations {
+		result[i] = &object{
+			typename: "AffectedQuery",
+			fields: map[string]any{
+				"id":            op.ID,
+				"name":          op.Name,
+				"operationName": op.Name,
+				"requestCount":  op.RequestCount,
+			},
+		}
+	}
+	return result
+}
+
+func affectedClientObjects(operations []Operation) []*object {
+	seen := map[string]bool{}
+	result := []*object{}
+	for _, op := range operations {
+		key := op.ClientName + "@" + op.ClientVersion
+		if op.ClientName == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, &object{
+			typename: "AffectedClient",
+			fields: map[string]any{
+				"clientName":    op.ClientName,
+				"clientVersion": op.ClientVersion,
+			},
+		})
+	}
+	return result
+}
+
+func diffSeverity(changes []change) string {
+	for _, c := range changes {
+		if c.Severity == "FAILURE" {
+			return "FAILURE"
+		}
+	}
+	return "NOTICE"
+}
+
+func changeObjects(changes []change) []*object {
+	result := make([]*object, len(changes))
+	for i, c := range changes {
+		parent, child, _ := strings.Cut(c.Coordinate, ".")
+		var childNode *object
+		if child != "" {
+			childNode = &object{
+				typename: "NamedIntrospectionValue",
+				fields: map[string]any{
+					"name": child,
+				},
+			}
+		}
+		result[i] = &object{
+			typename: "Change",
+			fields: map[string]any{
+				"severity":        c.Severity,
+				"code":            c.Code,
+				"category":        c.Category,
+				"description":     c.Description,
+				"affectedQueries": affectedQueryObjects(c.Affected),
+				"affectedClients": affectedClientObjects(c.Affected),
+				"parentNode": &object{
+					typename: "NamedIntrospectionType",
+					fields: map[string]any{
+						"name": parent,
+					},
+				},
+				"childNode": childNode,
+			},
+		}
+	}
+	return result
+}
+
+func (s *Server) mutationRoot() *object {
+	return &object{
+		typename: "Mutation",
+		fields: map[string]any{
+			"graph":   resolver(s.resolveGraphMutation),
+			"service": resolver(s.resolveGraphMutation),
+			"account": resolver(func(args map[string]any) (any, error) {
+				return s.accountMutation(stringArg(args, "id")), nil
+			}),
+		},
+	}
+}
+
+func (s *Server) accountMutation(accountID string) *object {
+	return &object{
+		typename: "AccountMutation",
+		fields: map[string]any{
+			"id": accountID,
+			"createGraph": resolver(func(args map[string]any) (any, error) {
+				id := stringArg(args, "id")
+				if _, ok := s.graphs[id]; ok {
+					return &object{
+						typename: "GraphCreationError",
+						fields: map[string]any{
+							"message": fmt.Sprintf("graph %s already exists", id),
+						},
+					}, nil
+				}
+
+				g := newGraph(id, stringArg(args, "title"), stringArg(args, "graphType"), accountID)
+				s.graphs[id] = g
+				return graphObject(g), nil
+			}),
+		},
+	}
+}
+
+func (s *Server) resolveGraphMutation(args map[string]any) (any, error) {
+	g, ok := s.graphs[stringArg(args, "id")]
+	if !ok {
+		return (*object)(nil), nil
+	}
+
+	return &object{
+		typename: "GraphMutation",
+		fields: map[string]any{
+			"id": g.id,
+			"updateTitle": resolver(func(args map[string]any) (any, error) {
+				g.title = stringArg(args, "title")
+				return graphObject(g), nil
+			}),
+			"updateDescription": resolver(func(args map[string]any) (any, error) {
+				description := stringArg(args, "description")
+				g.description = &description
+				return graphObject(g), nil
+			}),
+			"delete": resolver(func(args map[string]any) (any, error) {
+				delete(s.graphs, g.id)
+				return nil, nil
+			}),
+			"newKey": resolver(func(args map[string]any) (any, error) {
+				role := stringArg(args, "role")
+				if role == "" {
+					role = "GRAPH_ADMIN"
+				}
+				id := s.nextID("key")
+				key := &apiKey{
+					id:        id,
+					name:      stringArg(args, "keyName"),
+					token:     "service:" + g.id + ":" + hash(id)[:22],
+					role:      role,
+					createdAt: time.Now().UTC(),
+				}
+				g.apiKeys = append(g.apiKeys, key)
+				return apiKeyObject(key), nil
+			}),
+			"renameKey": resolver(func(args map[string]any) (any, error) {
+				key := g.apiKey(stringArg(args, "id"))
+				if key == nil {
+					return nil, fmt.Errorf("api key %s not found", stringArg(args, "id"))
+				}
+				key.name = stringArg(args, "newKeyName")
+				return apiKeyObject(key), nil
+			}),
+			"removeKey": resolver(func(args map[string]any) (any, error) {
+				id := stringArg(args, "id")
+				for i, key := range g.apiKeys {
+					if key.id == id {
+						g.apiKeys = append(g.apiKeys[:i], g.apiKeys[i+1:]...)
+						break
+					}
+				}
+				return nil, nil
+			}),
+			"newVariant": resolver(func(args map[string]any) (any, error) {
+				name := stringArg(args, "name")
+				if _, ok := g.variants[name]; ok {
+					return nil, fmt.Errorf("variant %s@%s already exists", g.id, name)
+				}
+				return variantObject(g.id, s.variantForWrite(g, name)), nil
+			}),
+			"variant": resolver(func(args map[string]any) (any, error) {
+				return s.variantMutation(g, g.variants[stringArg(args, "name")]), nil
+			}),
+			"publishSubgraph": resolver(func(args map[string]any) (any, error) {
+				return s.publishSubgraph(g, args), nil
+			}),
+			"removeImplementingServiceAndTriggerComposition": resolver(func(args map[string]any) (any, error) {
+				return s.removeSubgraph(g, args), nil
+			}),
+			"lintSchema": resolver(func(args map[string]any) (any, error) {
+				if s.lintErr != nil {
+					return nil, s.lintErr
+				}
+				return lintResultObject(lint(stringArg(args, "sdl"), stringArg(args, "baseSdl"))), nil
+			}),
+		},
+	}, nil
+}
+
+func (s *Server) variantMutation(g *graph, v *variant) *object {
+	if v == nil {
+		return nil
+	}
+
+	return &object{
+		typename: "GraphVariantMutation",
+		fields: map[string]any{
+			"id": g.id + "@" + v.name,
+			"updateURL": resolver(func(args map[string]any) (any, error) {
+				v.url = optionalStringArg(args, "url")
+				return variantObject(g.id, v), nil
+			}),
+			"updateVariantIsPublic": resolver(func(args map[string]any) (any, error) {
+				v.isPublic = boolArg(args, "isPublic")
+				return variantObject(g.id, v), nil
+			}),
+			"submitSubgraphCheckAsync": resolver(func(args map[string]any) (any, error) {
+				return s.submitSubgraphCheck(g, v, objectArg(args, "input")), nil
+			}),
+			"updateDefaultHeaders": resolver(func(args map[string]any) (any, error) {
+				v.defaultHeaders = optionalStringArg(args, "defaultHeaders")
+				return variantObject(g.id, v), nil
+			}),
+			"delete": resolver(func(args map[string]any) (any, error) {
+				delete(g.variants, v.name)
+				return &object{
+					typename: "DeleteSchemaTagResult",
+					fields: map[string]any{
+						"deleted": true,
+					},
+				}, nil
+			}),
+		},
+	}
+}
+
+func (s *Server) variantForWrite(g *graph, name string) *variant {
+	v, ok := g.variants[name]
+	if !ok {
+		v = &variant{name: name, subgraphs: map[string]*subgraph{}}
+		g.variants[name] = v
+	}
+	return v
+}
+
+// launch composes the current subgraphs of the variant and records the
+// result as the latest launch.
+func (s *Server) launch(v *variant) *launch {
+	var subgraphs []*subgraph
+	for _, name := range sortedKeys(v.subgraphs) {
+		subgraphs = append(subgraphs, v.subgraphs[name])
+	}
+
+	doc, errs := compose(subgraphs)
+	l := &launch{
+		id:           s.nextID("launch"),
+		createdAt:    time.Now().UTC(),
+		coreDocument: doc,
+		errors:       errs,
+	}
+	for _, sg := range subgraphs {
+		l.subgraphs = append(l.subgraphs, buildInput{name: sg.name, hash: hash(sg.sdl)})
+	}
+
+	v.launches = append(v.launches, l)
+	return l
+}
+
+func (s *Server) publishSubgraph(g *graph, args map[string]any) *object {
+	v := s.variantForWrite(g, stringArg(args, "graphVariant"))
+	name := stringArg(args, "name")
+	sdl := stringArg(objectArg(args, "activePartialSchema"), "sdl")
+
+	result := map[string]any{
+		"errors":            []*object{},
+		"wasCreated":        false,
+		"updatedGateway":    false,
+		"launchUrl":         nil,
+		"launchCliCopy":     nil,
+		"launch":            (*object)(nil),
+		"compositionConfig": (*object)(nil),
+	}
+
+	if errs := validateSubgraph(name, sdl); len(errs) > 0 {
+		result["errors"] = compositionErrorObjects(errs)
+		return &object{typename: "CompositionAndUpsertResult", fields: result}
+	}
+
+	now := time.Now().UTC()
+	sg, exists := v.subgraphs[name]
+	if !exists {
+		sg = &subgraph{name: name, createdAt: now}
+		v.subgraphs[name] = sg
+	}
+
+	revision := stringArg(args, "revision")
+	if revision == "" {
+		revision = hash(sdl)[:12]
+	}
+
+	if url := stringArg(args, "url"); url != "" {
+		sg.url = url
+	}
+	sg.sdl = sdl
+	sg.revision = revision
+	sg.updatedAt = now
+
+	l := s.launch(v)
+	result["errors"] = compositionErrorObjects(l.errors)
+	result["wasCreated"] = !exists
+	result["updatedGateway"] = len(l.errors) == 0
+	launchURL := fmt.Sprintf("https://studio.apollographql.com/graph/%s/launches/%s", g.id, l.id)
+	result["launchUrl"] = launchURL
+	result["launchCliCopy"] = fmt.Sprintf("You can monitor this launch in Apollo Studio: %s", launchURL)
+	result["launch"] = launchObject(l)
+	result["compositionConfig"] = &object{
+		typename: "CompositionPublishResult",
+		fields: map[string]any{
+			"schemaHash": hash(l.coreDocument),
+		},
+	}
+
+	return &object{typename: "CompositionAndUpsertResult", fields: result}
+}
+
+func (s *Server) removeSubgraph(g *graph, args map[string]any) *object {
+	result := map[string]any{
+		"errors":         []*object{},
+		"updatedGateway": false,
+	}
+
+	v, ok := g.variants[stringArg(args, "graphVariant")]
+	if !ok {
+		return &object{typename: "CompositionAndRemoveResult", fields: result}
+	}
+
+	name := stringArg(args, "name")
+	if _, ok := v.subgraphs[name]; !ok || boolArg(args, "dryRun") {
+		return &object{typename: "CompositionAndRemoveResult", fields: result}
+	}
+
+	delete(v.subgraphs, name)
+	l := s.launch(v)
+	result["errors"] = compositionErrorObjects(l.errors)
+	result["updatedGateway"] = len(l.errors) == 0
+
+	return &object{typename: "CompositionAndRemoveResult", fields: result}
+}
+
+// check runs the composition and operation checks of the subgraph schema
+// against the variant and records them as a check workflow of the graph.
+func (s *Server) check(g *graph, variantName, name, sdl string, params map[string]any) *checkWorkflow {
+	var previous string
+	if v, ok := g.variants[variantName]; ok {
+		if sg, ok := v.subgraphs[name]; ok {
+			previous = sg.sdl
+		}
+	}
+
+	now := time.Now().UTC()
+	from := now.Add(-checkWindow)
+	if t, err := time.Parse(time.RFC3339, stringArg(params, "from")); err == nil {
+		from = t
+	}
+	operations := filterOperations(g.operations[variantName], params, from)
+
+	changes := diff(previous, sdl)
+	checkOperations(changes, operations)
+
+	id := s.nextID("check")
+	w := &checkWorkflow{
+		id:                id,
+		createdAt:         now,
+		targetURL:         fmt.Sprintf("https://studio.apollographql.com/graph/%s/checks/%s", g.id, id),
+		compositionErrors: validateSubgraph(name, sdl),
+		changes:           changes,
+		checkedOperations: len(operations),
+		from:              from,
+		to:                now,
+	}
+	g.checks[id] = w
+	return w
+}
+
+func (s *Server) submitSubgraphCheck(g *graph, v *variant, input map[string]any) *object {
+	name := stringArg(input, "subgraphName")
+	if name == "" {
+		return &object{
+			typename: "InvalidInputError",
+			fields: map[string]any{
+				"message": "subgraphName is required",
+			},
+		}
+	}
+
+	w := s.check(g, v.name, name, stringArg(input, "proposedSchema"), objectArg(input, "config"))
+	return &object{
+		typename: "CheckRequestSuccess",
+		fields: map[string]any{
+			"targetURL":  w.targetURL,
+			"workflowID": w.id,
+		},
+	}
+}
+
+// taskStatus returns the status of a check task, a task fails when it has
+// failures.
+func taskStatus(failed bool) string {
+	if failed {
+		return "FAILED"
+	}
+	return "PASSED"
+}
+
+func checkWorkflowObject(w *checkWorkflow) *object {
+	if w == nil {
+		return nil
+	}
+
+	compositionFailed := len(w.compositionErrors) > 0
+	operationsFailed := diffSeverity(w.changes) == "FAILURE"
+
+	return &object{
+		typename: "CheckWorkflow",
+		fields: map[string]any{
+			"id":        w.id,
+			"status":    taskStatus(compositionFailed || operationsFailed),
+			"createdAt": w.createdAt,
+			"tasks": []*object{
+				{
+					typename: "CompositionCheckTask",
+					fields: map[string]any{
+						"status":    taskStatus(compositionFailed),
+						"targetURL": w.targetURL,
+						"result": &object{
+							typename: "CompositionCheckResult",
+							fields: map[string]any{
+								"graphCompositionID": w.id,
+								"errors":             compositionErrorObjects(w.compositionErrors),
+							},
+						},
+					},
+				},
+				{
+					typename: "OperationsCheckTask",
+					fields: map[string]any{
+						"status":    taskStatus(operationsFailed),
+						"targetURL": w.targetURL,
+						"result": &object{
+							typename: "OperationsCheckResult",
+							fields: map[string]any{
+								"checkSeverity":             diffSeverity(w.changes),
+								"numberOfCheckedOperations": w.checkedOperations,
+								"changes":                   changeObjects(w.changes),
+								"validationConfig": &object{
+									typename: "SchemaDiffValidationConfig",
+									fields: map[string]any{
+										"from": w.from,
+										"to":   w.to,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package mockserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/labd/apollostudio-go-sdk/apollostudio"
+)
+
+// TestSDKCompatibility runs the operations of the apollostudio SDK against the
+// mock server, so the mock serves every field the SDK queries.
+func TestSDKCompatibility(t *testing.T) {
+	s := New()
+	defer s.Close()
+	s.AddGraph("unittest")
+
+	ctx := context.Background()
+	client, err := apollostudio.NewClient("service:unittest:key", "unittest@current", apollostudio.WithUrl(s.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	submitted, err := client.SubmitSubGraph(ctx, &apollostudio.SubmitOptions{
+		SubGraphName:   "products",
+		SubGraphSchema: []byte(testSchema),
+		SubGraphURL:    "https://example.com/graphql",
+	})
+	if err != nil {
+		t.Fatalf("SubmitSubGraph() error = %v", err)
+	}
+	if !submitted.WasCreated || submitted.LaunchUrl == "" || submitted.LaunchCliCopy == "" {
+		t.Fatalf("unexpected submit result: %+v", submitted)
+	}
+
+	subgraph, err := client.GetSubGraph(ctx, "products")
+	if err != nil {
+		t.Fatalf("GetSubGraph() error = %v", err)
+	}
+	if subgraph.GraphID != "unittest" || subgraph.GraphVariant != "current" || subgraph.URL != "https://example.com/graphql" {
+		t.Fatalf("unexpected sub graph: %+v", subgraph)
+	}
+
+	build, err := client.GetLatestSchemaBuild(ctx)
+	if err != nil {
+		t.Fatalf("GetLatestSchemaBuild() error = %v", err)
+	}
+	if !build.ContainsGraph("products") {
+		t.Fatalf("latest build does not contain products: %+v", build)
+	}
+
+	valid, err := client.ValidateSubGraph(ctx, &apollostudio.ValidateOptions{
+		SubGraphName:   "products",
+		SubGraphSchema: []byte(testSchema),
+	})
+	if err != nil {
+		t.Fatalf("ValidateSubGraph() error = %v", err)
+	}
+	if !valid.IsValid() {
+		t.Fatalf("expected valid schema, got errors %v", valid.Errors())
+	}
+
+	invalid, err := client.ValidateSubGraph(ctx, &apollostudio.ValidateOptions{
+		SubGraphName:   "products",
+		SubGraphSchema: []byte("type Query { products: [Test] }"),
+	})
+	if err != nil {
+		t.Fatalf("ValidateSubGraph() error = %v", err)
+	}
+	if invalid.IsValid() || len(invalid.Errors()) == 0 {
+		t.Fatalf("expected invalid schema, got status %s", invalid.Status)
+	}
+
+	if err := client.RemoveSubGraph(ctx, "products"); err != nil {
+		t.Fatalf("RemoveSubGraph() error = %v", err)
+	}
+}
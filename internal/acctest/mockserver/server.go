@@ -0,0 +1,297 @@
+// Package mockserver implements an in-process fake of the Apollo Platform
+// API. It keeps graphs, variants and subgraphs in memory so the acceptance
+// tests can run without network access or Apollo Studio credentials.
+package mockserver
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+// schemaSDL is a trimmed copy of the Platform API schema, requests that are
+// not valid against it are rejected like the real API would.
+//
+//go:embed schema.graphql
+var schemaSDL string
+
+var platformSchema = gqlparser.MustLoadSchema(&ast.Source{Name: "schema.graphql", Input: schemaSDL})
+
+// Server is a running mock of the Apollo Platform API.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	graphs   map[string]*graph
+	sequence int
+	// lintErr is returned by the lintSchema mutation when set.
+	lintErr error
+}
+
+type graph struct {
+	id          string
+	title       string
+	description *string
+	graphType   string
+	accountID   string
+	variants    map[string]*variant
+	apiKeys     []*apiKey
+	// operations holds the client operations reported per variant name, the
+	// operation checks of a variant only consider its own traffic.
+	operations map[string][]Operation
+	// checks holds the check workflows submitted to the graph by ID.
+	checks map[string]*checkWorkflow
+}
+
+type apiKey struct {
+	id        string
+	name      string
+	token     string
+	role      string
+	createdAt time.Time
+}
+
+type variant struct {
+	name           string
+	url            *string
+	isPublic       bool
+	defaultHeaders *string
+	subgraphs      map[string]*subgraph
+	launches       []*launch
+}
+
+type subgraph struct {
+	name      string
+	url       string
+	sdl       string
+	revision  string
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+type launch struct {
+	id           string
+	createdAt    time.Time
+	subgraphs    []buildInput
+	coreDocument string
+	errors       []compositionError
+	// pending launches are still building and have no build result.
+	pending bool
+}
+
+// buildInput is a subgraph as it was composed by a launch.
+type buildInput struct {
+	name string
+	hash string
+}
+
+// checkWorkflow is a subgraph check, the mock runs the checks when the
+// workflow is submitted so it is never pending.
+type checkWorkflow struct {
+	id                string
+	createdAt         time.Time
+	targetURL         string
+	compositionErrors []compositionError
+	changes           []change
+	checkedOperations int
+	from              time.Time
+	to                time.Time
+}
+
+// Operation is a client operation reported to a variant. The operation checks
+// treat a change to any of the schema coordinates in Fields as breaking it.
+type Operation struct {
+	ID            string
+	Name          string
+	ClientName    string
+	ClientVersion string
+	Fields        []string
+	// RequestCount is the number of requests of the operation within the
+	// check window.
+	RequestCount int
+	// LastSeen is when the operation was last requested, operations without
+	// it fall within every check window.
+	LastSeen time.Time
+}
+
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+type responseError struct {
+	Message string `json:"message"`
+}
+
+type response struct {
+	Data   any             `json:"data"`
+	Errors []responseError `json:"errors,omitempty"`
+}
+
+// New starts a new mock server. Call Close when done.
+func New() *Server {
+	s := &Server{
+		graphs: map[string]*graph{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// AddGraph registers an empty graph. Subgraphs can only be published to
+// graphs that exist.
+func (s *Server) AddGraph(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.graphs[id]; !ok {
+		s.graphs[id] = newGraph(id, id, "SELF_HOSTED_SUPERGRAPH", "unittest")
+	}
+}
+
+func newGraph(id, title, graphType, accountID string) *graph {
+	return &graph{
+		id:         id,
+		title:      title,
+		graphType:  graphType,
+		accountID:  accountID,
+		variants:   map[string]*variant{},
+		operations: map[string][]Operation{},
+		checks:     map[string]*checkWorkflow{},
+	}
+}
+
+// AddOperation reports an operation as traffic of the variant of the given
+// graph ref, so the operation checks of that variant take it into account.
+func (s *Server) AddOperation(graphRef string, op Operation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	graphID, name, _ := strings.Cut(graphRef, "@")
+	g, ok := s.graphs[graphID]
+	if !ok {
+		g = newGraph(graphID, graphID, "SELF_HOSTED_SUPERGRAPH", "unittest")
+		s.graphs[graphID] = g
+	}
+	g.operations[name] = append(g.operations[name], op)
+}
+
+// SetLintError makes the lintSchema mutation fail with err, pass nil to lint
+// schemas again.
+func (s *Server) SetLintError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lintErr = err
+}
+
+// AddPendingLaunch records a launch of the variant of the given graph ref that
+// is still building, it stays the latest launch until the next publish.
+func (s *Server) AddPendingLaunch(graphRef string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	graphID, name, _ := strings.Cut(graphRef, "@")
+	g, ok := s.graphs[graphID]
+	if !ok {
+		g = newGraph(graphID, graphID, "SELF_HOSTED_SUPERGRAPH", "unittest")
+		s.graphs[graphID] = g
+	}
+	v := s.variantForWrite(g, name)
+	l := &launch{
+		id:        s.nextID("launch"),
+		createdAt: time.Now().UTC(),
+		pending:   true,
+	}
+	for _, sgName := range sortedKeys(v.subgraphs) {
+		sg := v.subgraphs[sgName]
+		l.subgraphs = append(l.subgraphs, buildInput{name: sg.name, hash: hash(sg.sdl)})
+	}
+	v.launches = append(v.launches, l)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := s.execute(req)
+	resp := response{Data: data}
+	if err != nil {
+		resp = response{Errors: []responseError{{Message: err.Error()}}}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) execute(req request) (any, error) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: req.Query})
+	if err != nil {
+		return nil, err
+	}
+	if errs := validator.ValidateWithRules(platformSchema, doc, nil); len(errs) > 0 {
+		return nil, errs
+	}
+
+	var op *ast.OperationDefinition
+	if req.OperationName != "" {
+		op = doc.Operations.ForName(req.OperationName)
+	} else if len(doc.Operations) > 0 {
+		op = doc.Operations[0]
+	}
+	if op == nil {
+		return nil, errors.New("operation not found")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	root := s.queryRoot()
+	if op.Operation == ast.Mutation {
+		root = s.mutationRoot()
+	}
+
+	e := &executor{doc: doc, vars: req.Variables}
+	return e.resolve(op.SelectionSet, root)
+}
+
+func (s *Server) nextID(prefix string) string {
+	s.sequence++
+	return fmt.Sprintf("%s-%d", prefix, s.sequence)
+}
+
+func (s *Server) lookupVariant(graphID, name string) *variant {
+	g, ok := s.graphs[graphID]
+	if !ok {
+		return nil
+	}
+	return g.variants[name]
+}
+
+func (g *graph) apiKey(id string) *apiKey {
+	for _, key := range g.apiKeys {
+		if key.id == id {
+			return key
+		}
+	}
+	return nil
+}
@@ -0,0 +1,332 @@
+package mockserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testSchema = "type Query { products: [Product] } type Product @key(fields: \"id\") { id: ID! name: String }"
+
+func post(t *testing.T, s *Server, query string, variables map[string]any) map[string]any {
+	t.Helper()
+
+	data, errs := send(t, s, query, variables)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	return data
+}
+
+// send posts the query and returns both the data and the error messages of
+// the response.
+func send(t *testing.T, s *Server, query string, variables map[string]any) (map[string]any, []string) {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.Post(s.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data   map[string]any `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+
+	errs := make([]string, len(result.Errors))
+	for i, e := range result.Errors {
+		errs[i] = e.Message
+	}
+	return result.Data, errs
+}
+
+func publish(t *testing.T, s *Server, name, sdl string) map[string]any {
+	t.Helper()
+
+	data := post(t, s, `
+		mutation Publish($graph: ID!, $name: String!, $url: String, $schema: PartialSchemaInput!) {
+		  graph(id: $graph) {
+		    publishSubgraph(graphVariant: "current", name: $name, url: $url, revision: "", activePartialSchema: $schema) {
+		      wasCreated
+		      updatedGateway
+		      errors { message code }
+		    }
+		  }
+		}`,
+		map[string]any{
+			"graph":  "unittest",
+			"name":   name,
+			"url":    "https://example.com/graphql",
+			"schema": map[string]any{"sdl": sdl},
+		},
+	)
+	return lookup(data, "graph", "publishSubgraph")
+}
+
+func lookup(data map[string]any, keys ...string) map[string]any {
+	for _, k := range keys {
+		next, _ := data[k].(map[string]any)
+		data = next
+	}
+	return data
+}
+
+func TestPublishAndFetchSubgraph(t *testing.T) {
+	s := New()
+	defer s.Close()
+	s.AddGraph("unittest")
+
+	result := publish(t, s, "products", testSchema)
+	if result["wasCreated"] != true || result["updatedGateway"] != true {
+		t.Fatalf("unexpected publish result: %v", result)
+	}
+
+	data := post(t, s, `
+		query ($ref: ID!, $name: ID!) {
+		  variant(ref: $ref) {
+		    __typename
+		    ... on GraphVariant {
+		      subgraph(name: $name) { name url activePartialSchema { sdl } }
+		      latestLaunch { build { result { ... on BuildSuccess { coreSchema { coreHash } } } } }
+		    }
+		  }
+		}`,
+		map[string]any{"ref": "unittest@current", "name": "products"},
+	)
+
+	variant := lookup(data, "variant")
+	if variant["__typename"] != "GraphVariant" {
+		t.Fatalf("unexpected variant: %v", variant)
+	}
+	subgraph := lookup(variant, "subgraph")
+	if subgraph["url"] != "https://example.com/graphql" {
+		t.Errorf("unexpected url: %v", subgraph["url"])
+	}
+	if lookup(subgraph, "activePartialSchema")["sdl"] != testSchema {
+		t.Errorf("unexpected sdl: %v", subgraph["activePartialSchema"])
+	}
+	if lookup(variant, "latestLaunch", "build", "result", "coreSchema")["coreHash"] == "" {
+		t.Errorf("expected a core hash")
+	}
+}
+
+func TestRejectInvalidQuery(t *testing.T) {
+	s := New()
+	defer s.Close()
+	s.AddGraph("unittest")
+
+	data, errs := send(t, s, `{ graph(id: "unittest") { id owner } }`, nil)
+	if data != nil || len(errs) != 1 || !strings.Contains(errs[0], `Cannot query field "owner" on type "Graph"`) {
+		t.Fatalf("expected the unknown field to be rejected, got %v %v", data, errs)
+	}
+
+	_, errs = send(t, s, `mutation { graph(id: "unittest") { lintSchema(baseSdl: "type Query { a: String }") { diagnostics { rule } } } }`, nil)
+	if len(errs) != 1 || !strings.Contains(errs[0], `argument "sdl" of type "String!" is required`) {
+		t.Fatalf("expected the missing argument to be rejected, got %v", errs)
+	}
+}
+
+func TestPublishInvalidSchema(t *testing.T) {
+	s := New()
+	defer s.Close()
+	s.AddGraph("unittest")
+
+	result := publish(t, s, "products", "type Query { products: [Missing] }")
+	errs, _ := result["errors"].([]any)
+	if len(errs) != 1 || result["wasCreated"] != false {
+		t.Fatalf("expected a composition error, got %v", result)
+	}
+}
+
+func TestPendingLaunch(t *testing.T) {
+	s := New()
+	defer s.Close()
+	s.AddGraph("unittest")
+
+	publish(t, s, "products", testSchema)
+	s.AddPendingLaunch("unittest@current")
+
+	data := post(t, s, `
+		query ($ref: ID!) {
+		  variant(ref: $ref) {
+		    ... on GraphVariant {
+		      latestLaunch { status build { result { __typename } } }
+		    }
+		  }
+		}`,
+		map[string]any{"ref": "unittest@current"},
+	)
+
+	launch := lookup(data, "variant", "latestLaunch")
+	if launch["status"] != "LAUNCH_INITIATED" {
+		t.Errorf("unexpected status: %v", launch["status"])
+	}
+	if result := lookup(launch, "build")["result"]; result != nil {
+		t.Errorf("expected no build result, got %v", result)
+	}
+}
+
+// check submits the subgraph schema for checking and returns the check
+// workflow.
+func check(t *testing.T, s *Server, name, sdl string) map[string]any {
+	t.Helper()
+
+	data := post(t, s, `
+		mutation Check($input: SubgraphCheckAsyncInput!) {
+		  graph(id: "unittest") {
+		    variant(name: "current") {
+		      submitSubgraphCheckAsync(input: $input) {
+		        ... on CheckRequestSuccess { workflowID }
+		      }
+		    }
+		  }
+		}`,
+		map[string]any{
+			"input": map[string]any{
+				"graphRef":       "unittest@current",
+				"subgraphName":   name,
+				"proposedSchema": sdl,
+				"config":         map[string]any{},
+			},
+		},
+	)
+	id, _ := lookup(data, "graph", "variant", "submitSubgraphCheckAsync")["workflowID"].(string)
+	if id == "" {
+		t.Fatalf("expected a workflow ID, got %v", data)
+	}
+
+	data = post(t, s, `
+		query Workflow($id: ID!) {
+		  graph(id: "unittest") {
+		    checkWorkflow(id: $id) {
+		      status
+		      tasks {
+		        __typename
+		        status
+		        ... on CompositionCheckTask { result { errors { code } } }
+		        ... on OperationsCheckTask {
+		          result { changes { code description severity affectedQueries { id name } } }
+		        }
+		      }
+		    }
+		  }
+		}`,
+		map[string]any{"id": id},
+	)
+	return lookup(data, "graph", "checkWorkflow")
+}
+
+// task returns the result of the task of the check workflow with the given
+// type.
+func task(workflow map[string]any, typename string) map[string]any {
+	tasks, _ := workflow["tasks"].([]any)
+	for _, t := range tasks {
+		if t, _ := t.(map[string]any); t["__typename"] == typename {
+			return t
+		}
+	}
+	return nil
+}
+
+func TestCheckAndRemoveSubgraph(t *testing.T) {
+	s := New()
+	defer s.Close()
+	s.AddGraph("unittest")
+
+	publish(t, s, "products", testSchema)
+
+	workflow := check(t, s, "products", "type Query { products: [Product] } type Product { id: ID! }")
+	if workflow["status"] != "PASSED" {
+		t.Fatalf("expected a passing check, got %v", workflow)
+	}
+
+	changes, _ := lookup(task(workflow, "OperationsCheckTask"), "result")["changes"].([]any)
+	if len(changes) != 1 {
+		t.Fatalf("expected one change, got %v", changes)
+	}
+	if change, _ := changes[0].(map[string]any); change["code"] != "FIELD_REMOVED" {
+		t.Fatalf("unexpected change: %v", change)
+	}
+
+	data := post(t, s, `
+		mutation {
+		  graph(id: "unittest") {
+		    removeImplementingServiceAndTriggerComposition(graphVariant: "current", name: "products", dryRun: false) {
+		      updatedGateway
+		    }
+		  }
+		}`,
+		nil,
+	)
+	if lookup(data, "graph", "removeImplementingServiceAndTriggerComposition")["updatedGateway"] != true {
+		t.Fatalf("unexpected remove result: %v", data)
+	}
+
+	data = post(t, s, `{ graph(id: "unittest") { variant(name: "current") { subgraphs { name } } } }`, nil)
+	if subgraphs, _ := lookup(data, "graph", "variant")["subgraphs"].([]any); len(subgraphs) != 0 {
+		t.Fatalf("expected no subgraphs, got %v", subgraphs)
+	}
+}
+
+func TestCheckBreakingChange(t *testing.T) {
+	s := New()
+	defer s.Close()
+	s.AddGraph("unittest")
+	s.AddOperation("unittest@current", Operation{ID: "op-1", Name: "ProductNames", Fields: []string{"Product.name"}})
+
+	publish(t, s, "products", testSchema)
+
+	workflow := check(t, s, "products", "type Query { products: [Product] } type Product { id: ID! }")
+	operations := task(workflow, "OperationsCheckTask")
+	if workflow["status"] != "FAILED" || operations["status"] != "FAILED" {
+		t.Fatalf("expected a failing check, got %v", workflow)
+	}
+	if composition := task(workflow, "CompositionCheckTask"); composition["status"] != "PASSED" {
+		t.Fatalf("expected the composition check to pass, got %v", composition)
+	}
+	changes, _ := lookup(operations, "result")["changes"].([]any)
+	change, _ := changes[0].(map[string]any)
+	queries, _ := change["affectedQueries"].([]any)
+	if change["severity"] != "FAILURE" || len(queries) != 1 {
+		t.Fatalf("expected the change to break one operation, got %v", change)
+	}
+}
+
+func TestLintSchema(t *testing.T) {
+	s := New()
+	defer s.Close()
+	s.AddGraph("unittest")
+
+	data := post(t, s, `
+		mutation ($sdl: String!, $baseSdl: String) {
+		  graph(id: "unittest") {
+		    lintSchema(sdl: $sdl, baseSdl: $baseSdl) { diagnostics { rule level coordinate } }
+		  }
+		}`,
+		map[string]any{
+			"sdl":     "type Query { product_name: String old: String @deprecated }",
+			"baseSdl": "type Query { product_name: String }",
+		},
+	)
+
+	diagnostics, _ := lookup(data, "graph", "lintSchema")["diagnostics"].([]any)
+	if len(diagnostics) != 1 {
+		t.Fatalf("expected one new violation, got %v", diagnostics)
+	}
+	if d, _ := diagnostics[0].(map[string]any); d["rule"] != "DEPRECATED_DIRECTIVE_MISSING_REASON" || d["level"] != "ERROR" {
+		t.Fatalf("unexpected violation: %v", d)
+	}
+}
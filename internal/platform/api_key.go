@@ -0,0 +1,131 @@
+package platform
+
+import (
+	"context"
+	"time"
+
+	"github.com/hasura/go-graphql-client"
+)
+
+// UserPermission is the role granted to a graph API key.
+type UserPermission string
+
+const (
+	UserPermissionGraphAdmin  UserPermission = "GRAPH_ADMIN"
+	UserPermissionContributor UserPermission = "CONTRIBUTOR"
+	UserPermissionDocumenter  UserPermission = "DOCUMENTER"
+	UserPermissionObserver    UserPermission = "OBSERVER"
+)
+
+type APIKey struct {
+	ID        string         `graphql:"id"`
+	KeyName   *string        `graphql:"keyName"`
+	Role      UserPermission `graphql:"role"`
+	CreatedAt time.Time      `graphql:"createdAt"`
+}
+
+// NewAPIKey is a newly created API key, the token is only returned once.
+type NewAPIKey struct {
+	APIKey
+	Token string `graphql:"token"`
+}
+
+type CreateAPIKeyOptions struct {
+	GraphID string
+	Name    string
+	Role    UserPermission
+}
+
+// GetAPIKey returns the API key of the graph with the given ID, or nil when
+// it does not exist.
+func (c *Client) GetAPIKey(ctx context.Context, graphID, id string) (*APIKey, error) {
+	var q struct {
+		Graph *struct {
+			APIKeys []APIKey `graphql:"apiKeys"`
+		} `graphql:"graph(id: $graphId)"`
+	}
+
+	err := c.gql.Query(ctx, &q, map[string]any{
+		"graphId": graphql.ID(graphID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if q.Graph == nil {
+		return nil, nil
+	}
+	for i := range q.Graph.APIKeys {
+		if q.Graph.APIKeys[i].ID == id {
+			return &q.Graph.APIKeys[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *Client) CreateAPIKey(ctx context.Context, opts *CreateAPIKeyOptions) (*NewAPIKey, error) {
+	var m struct {
+		Graph *struct {
+			NewKey NewAPIKey `graphql:"newKey(keyName: $keyName, role: $role)"`
+		} `graphql:"graph(id: $graphId)"`
+	}
+
+	err := c.gql.Mutate(ctx, &m, map[string]any{
+		"graphId": graphql.ID(opts.GraphID),
+		"keyName": opts.Name,
+		"role":    opts.Role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Graph == nil {
+		return nil, &OperationError{Message: "graph " + opts.GraphID + " not found"}
+	}
+	return &m.Graph.NewKey, nil
+}
+
+func (c *Client) RenameAPIKey(ctx context.Context, graphID, id, name string) error {
+	var m struct {
+		Graph *struct {
+			RenameKey struct {
+				ID string `graphql:"id"`
+			} `graphql:"renameKey(id: $keyId, newKeyName: $keyName)"`
+		} `graphql:"graph(id: $graphId)"`
+	}
+
+	err := c.gql.Mutate(ctx, &m, map[string]any{
+		"graphId": graphql.ID(graphID),
+		"keyId":   graphql.ID(id),
+		"keyName": name,
+	})
+	if err != nil {
+		return err
+	}
+
+	if m.Graph == nil {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (c *Client) DeleteAPIKey(ctx context.Context, graphID, id string) error {
+	var m struct {
+		Graph *struct {
+			RemoveKey *bool `graphql:"removeKey(id: $keyId)"`
+		} `graphql:"graph(id: $graphId)"`
+	}
+
+	err := c.gql.Mutate(ctx, &m, map[string]any{
+		"graphId": graphql.ID(graphID),
+		"keyId":   graphql.ID(id),
+	})
+	if err != nil {
+		return err
+	}
+
+	if m.Graph == nil {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,411 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hasura/go-graphql-client"
+)
+
+// ChangeClass classifies a change by its impact on clients.
+type ChangeClass string
+
+const (
+	// ChangeBreaking is a change that breaks operations of the variant.
+	ChangeBreaking ChangeClass = "breaking"
+	// ChangeDangerous is a change that removes or alters part of the schema
+	// without breaking any of the checked operations.
+	ChangeDangerous ChangeClass = "dangerous"
+	// ChangeSafe is a change that only extends the schema.
+	ChangeSafe ChangeClass = "safe"
+)
+
+// AffectedQuery is an operation broken by a change, RequestCount is the
+// number of requests of the operation within the check window.
+type AffectedQuery struct {
+	ID           string  `graphql:"id"`
+	Name         *string `graphql:"name"`
+	RequestCount int64   `graphql:"requestCount"`
+}
+
+// AffectedClient is a client that sent operations broken by a change.
+type AffectedClient struct {
+	ClientName    *string `graphql:"clientName"`
+	ClientVersion *string `graphql:"clientVersion"`
+}
+
+// Change is a difference between the checked schema and the schema published
+// to the variant.
+type Change struct {
+	Severity        string           `graphql:"severity"`
+	Code            string           `graphql:"code"`
+	Category        string           `graphql:"category"`
+	Description     string           `graphql:"description"`
+	AffectedQueries []AffectedQuery  `graphql:"affectedQueries"`
+	AffectedClients []AffectedClient `graphql:"affectedClients"`
+	ParentNode      *struct {
+		Name string `graphql:"name"`
+	} `graphql:"parentNode"`
+	ChildNode *struct {
+		Name string `graphql:"name"`
+	} `graphql:"childNode"`
+}
+
+// Coordinate returns the schema coordinate the change applies to, for
+// example `Query.products`.
+func (c Change) Coordinate() string {
+	if c.ParentNode == nil {
+		return ""
+	}
+	if c.ChildNode == nil {
+		return c.ParentNode.Name
+	}
+	return c.ParentNode.Name + "." + c.ChildNode.Name
+}
+
+// String formats the change as `[SEVERITY]: description`.
+func (c Change) String() string {
+	return fmt.Sprintf("[%s]: %s", c.Severity, c.Description)
+}
+
+// Class classifies the change, a change that fails the operation checks is
+// breaking and any other change that is not an addition is dangerous.
+func (c Change) Class() ChangeClass {
+	switch {
+	case c.Severity == "FAILURE":
+		return ChangeBreaking
+	case c.Category == "ADDITION":
+		return ChangeSafe
+	default:
+		return ChangeDangerous
+	}
+}
+
+// CheckSubGraphOptions configures a subgraph check. The operation checks use
+// the server defaults for every option that is left empty.
+type CheckSubGraphOptions struct {
+	GraphRef string
+	Name     string
+	Schema   string
+
+	// Window is how far back the operation checks look for traffic.
+	Window time.Duration
+	// QueryCountThreshold is the minimum number of requests an operation
+	// needs within the window to be checked.
+	QueryCountThreshold int
+	// ExcludedClients are the names of the clients whose operations are
+	// not checked.
+	ExcludedClients []string
+	// ExcludedOperations are the names of the operations that are not
+	// checked.
+	ExcludedOperations []string
+}
+
+// ClientInfoFilter selects the operations of a client by name.
+type ClientInfoFilter struct {
+	Name string `json:"name"`
+}
+
+// OperationNameFilterInput selects operations by name.
+type OperationNameFilterInput struct {
+	Name string `json:"name"`
+}
+
+// HistoricQueryParametersInput selects the traffic the operation checks run
+// against.
+type HistoricQueryParametersInput struct {
+	From                   *time.Time                 `json:"from,omitempty"`
+	QueryCountThreshold    *int                       `json:"queryCountThreshold,omitempty"`
+	ExcludedClients        []ClientInfoFilter         `json:"excludedClients,omitempty"`
+	ExcludedOperationNames []OperationNameFilterInput `json:"excludedOperationNames,omitempty"`
+}
+
+// SubgraphCheckAsyncInput submits a subgraph schema for checking.
+type SubgraphCheckAsyncInput struct {
+	Config         HistoricQueryParametersInput `json:"config"`
+	GraphRef       string                       `json:"graphRef"`
+	IsSandbox      bool                         `json:"isSandbox"`
+	ProposedSchema string                       `json:"proposedSchema"`
+	SubgraphName   string                       `json:"subgraphName"`
+}
+
+// historicParameters returns the parameters for the options, the server
+// defaults apply to every parameter that is left empty.
+func (o *CheckSubGraphOptions) historicParameters() HistoricQueryParametersInput {
+	params := HistoricQueryParametersInput{}
+	if o.Window > 0 {
+		from := time.Now().UTC().Add(-o.Window)
+		params.From = &from
+	}
+	if o.QueryCountThreshold > 0 {
+		params.QueryCountThreshold = &o.QueryCountThreshold
+	}
+	for _, name := range o.ExcludedClients {
+		params.ExcludedClients = append(params.ExcludedClients, ClientInfoFilter{Name: name})
+	}
+	for _, name := range o.ExcludedOperations {
+		params.ExcludedOperationNames = append(params.ExcludedOperationNames, OperationNameFilterInput{Name: name})
+	}
+	return params
+}
+
+// CheckStatus is the status of a check workflow or one of its tasks.
+type CheckStatus string
+
+const (
+	CheckBlocked CheckStatus = "BLOCKED"
+	CheckFailed  CheckStatus = "FAILED"
+	CheckPassed  CheckStatus = "PASSED"
+	CheckPending CheckStatus = "PENDING"
+)
+
+// checkPollInterval is the time between two polls of a pending check workflow.
+const checkPollInterval = time.Second
+
+// CheckError is an error reported by a failed check task.
+type CheckError struct {
+	Message string
+	Code    string
+}
+
+// CheckResult is the result of checking a subgraph schema against a variant.
+// The operations are checked against the traffic between WindowFrom and
+// WindowTo, both are zero when no operation checks ran.
+type CheckResult struct {
+	WorkflowID                string
+	TargetURL                 string
+	Status                    CheckStatus
+	CompositionStatus         CheckStatus
+	OperationsStatus          CheckStatus
+	CompositionErrors         []CompositionError
+	Changes                   []Change
+	NumberOfCheckedOperations int
+	WindowFrom                time.Time
+	WindowTo                  time.Time
+}
+
+// IsValid reports whether the check workflow passed.
+func (r *CheckResult) IsValid() bool {
+	return r.Status == CheckPassed
+}
+
+// CompositionFailed reports whether the composition check failed.
+func (r *CheckResult) CompositionFailed() bool {
+	return r.CompositionStatus == CheckFailed
+}
+
+// OperationsFailed reports whether the operation checks failed.
+func (r *CheckResult) OperationsFailed() bool {
+	return r.OperationsStatus == CheckFailed
+}
+
+// CompositionCheckErrors returns the errors of the composition check, the
+// source locations are appended to the message.
+func (r *CheckResult) CompositionCheckErrors() []CheckError {
+	var errs []CheckError
+	for _, e := range r.CompositionErrors {
+		locations := make([]string, len(e.Locations))
+		for i, l := range e.Locations {
+			locations[i] = fmt.Sprintf("line %d, column %d", l.Line, l.Column)
+		}
+
+		err := CheckError{Message: e.Message}
+		if len(locations) > 0 {
+			err.Message = fmt.Sprintf("%s at %s", e.Message, strings.Join(locations, "; "))
+		}
+		if e.Code != nil {
+			err.Code = *e.Code
+		}
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// OperationsCheckErrors returns every change of the operation checks as an
+// error.
+func (r *CheckResult) OperationsCheckErrors() []CheckError {
+	var errs []CheckError
+	for _, c := range r.Changes {
+		errs = append(errs, CheckError{Message: c.String(), Code: c.Code})
+	}
+	return errs
+}
+
+// Errors returns the errors of the failed tasks.
+func (r *CheckResult) Errors() []CheckError {
+	var errs []CheckError
+	if r.CompositionFailed() {
+		errs = append(errs, r.CompositionCheckErrors()...)
+	}
+	if r.OperationsFailed() {
+		errs = append(errs, r.OperationsCheckErrors()...)
+	}
+	return errs
+}
+
+// BreakingChanges returns the number of changes that break operations.
+func (r *CheckResult) BreakingChanges() int {
+	count := 0
+	for _, c := range r.Changes {
+		if c.Class() == ChangeBreaking {
+			count++
+		}
+	}
+	return count
+}
+
+// CheckSubGraph runs the composition and operation checks for the subgraph
+// schema against the variant of the given graph ref. The checks run in a
+// workflow, which is polled until it is done or the context expires.
+func (c *Client) CheckSubGraph(ctx context.Context, opts *CheckSubGraphOptions) (*CheckResult, error) {
+	graphID, variant, err := splitGraphRef(opts.GraphRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var m struct {
+		Graph *struct {
+			Variant *struct {
+				SubmitSubgraphCheckAsync struct {
+					Typename            string `graphql:"__typename"`
+					CheckRequestSuccess struct {
+						TargetURL  string  `graphql:"targetURL"`
+						WorkflowID *string `graphql:"workflowID"`
+					} `graphql:"... on CheckRequestSuccess"`
+					InvalidInputError struct {
+						Message string `graphql:"message"`
+					} `graphql:"... on InvalidInputError"`
+					PermissionError struct {
+						Message string `graphql:"message"`
+					} `graphql:"... on PermissionError"`
+					PlanError struct {
+						Message string `graphql:"message"`
+					} `graphql:"... on PlanError"`
+				} `graphql:"submitSubgraphCheckAsync(input: $input)"`
+			} `graphql:"variant(name: $variant)"`
+		} `graphql:"graph(id: $graphId)"`
+	}
+
+	err = c.gql.Mutate(ctx, &m, map[string]any{
+		"graphId": graphql.ID(graphID),
+		"variant": variant,
+		"input": SubgraphCheckAsyncInput{
+			Config:         opts.historicParameters(),
+			GraphRef:       opts.GraphRef,
+			ProposedSchema: opts.Schema,
+			SubgraphName:   opts.Name,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Graph == nil || m.Graph.Variant == nil {
+		return nil, &OperationError{Message: "graph ref " + opts.GraphRef + " not found"}
+	}
+
+	submitted := m.Graph.Variant.SubmitSubgraphCheckAsync
+	switch submitted.Typename {
+	case "InvalidInputError":
+		return nil, &OperationError{Message: submitted.InvalidInputError.Message}
+	case "PermissionError":
+		return nil, &OperationError{Message: submitted.PermissionError.Message}
+	case "PlanError":
+		return nil, &OperationError{Message: submitted.PlanError.Message}
+	}
+	if submitted.CheckRequestSuccess.WorkflowID == nil {
+		return nil, &OperationError{Message: "could not create check workflow for subgraph " + opts.Name}
+	}
+
+	result, err := c.checkWorkflow(ctx, graphID, *submitted.CheckRequestSuccess.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+	result.TargetURL = submitted.CheckRequestSuccess.TargetURL
+	return result, nil
+}
+
+// checkWorkflow polls the check workflow until it is no longer pending.
+func (c *Client) checkWorkflow(ctx context.Context, graphID, workflowID string) (*CheckResult, error) {
+	for {
+		var q struct {
+			Graph *struct {
+				CheckWorkflow *struct {
+					Status string `graphql:"status"`
+					Tasks  []struct {
+						Typename            string `graphql:"__typename"`
+						OperationsCheckTask struct {
+							Status string `graphql:"status"`
+							Result *struct {
+								NumberOfCheckedOperations int      `graphql:"numberOfCheckedOperations"`
+								Changes                   []Change `graphql:"changes"`
+								ValidationConfig          *struct {
+									From time.Time `graphql:"from"`
+									To   time.Time `graphql:"to"`
+								} `graphql:"validationConfig"`
+							} `graphql:"result"`
+						} `graphql:"... on OperationsCheckTask"`
+						CompositionCheckTask struct {
+							Status string `graphql:"status"`
+							Result *struct {
+								Errors []CompositionError `graphql:"errors"`
+							} `graphql:"result"`
+						} `graphql:"... on CompositionCheckTask"`
+					} `graphql:"tasks"`
+				} `graphql:"checkWorkflow(id: $workflowId)"`
+			} `graphql:"graph(id: $graphId)"`
+		}
+
+		err := c.gql.Query(ctx, &q, map[string]any{
+			"graphId":    graphql.ID(graphID),
+			"workflowId": graphql.ID(workflowID),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if q.Graph == nil || q.Graph.CheckWorkflow == nil {
+			return nil, &OperationError{Message: "check workflow " + workflowID + " not found"}
+		}
+
+		workflow := q.Graph.CheckWorkflow
+		switch status := CheckStatus(workflow.Status); status {
+		case CheckBlocked, CheckFailed, CheckPassed:
+			result := &CheckResult{
+				WorkflowID: workflowID,
+				Status:     status,
+				Changes:    []Change{},
+			}
+			for _, task := range workflow.Tasks {
+				switch task.Typename {
+				case "CompositionCheckTask":
+					t := task.CompositionCheckTask
+					result.CompositionStatus = CheckStatus(t.Status)
+					if t.Result != nil {
+						result.CompositionErrors = t.Result.Errors
+					}
+				case "OperationsCheckTask":
+					t := task.OperationsCheckTask
+					result.OperationsStatus = CheckStatus(t.Status)
+					if t.Result != nil {
+						result.Changes = t.Result.Changes
+						result.NumberOfCheckedOperations = t.Result.NumberOfCheckedOperations
+						if cfg := t.Result.ValidationConfig; cfg != nil {
+							result.WindowFrom = cfg.From
+							result.WindowTo = cfg.To
+						}
+					}
+				}
+			}
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(checkPollInterval):
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Package platform is a client for the parts of the Apollo Platform API that
+// are not covered by the apollostudio SDK, such as managing graphs and
+// variants.
+package platform
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hasura/go-graphql-client"
+)
+
+// DefaultEndpoint is the public Apollo Platform API endpoint.
+const DefaultEndpoint = "https://graphql.api.apollographql.com/api/graphql"
+
+// Client talks to the Apollo Platform API.
+type Client struct {
+	gql *graphql.Client
+}
+
+// NewClient creates a client for the given endpoint, authenticating with the
+// given API key.
+func NewClient(endpoint, key string, httpClient *http.Client) *Client {
+	gql := graphql.NewClient(endpoint, httpClient).WithRequestModifier(
+		func(r *http.Request) {
+			r.Header.Set("x-api-key", key)
+			r.Header.Set("apollographql-client-name", "terraform-provider-apollostudio")
+		},
+	)
+
+	return &Client{gql: gql}
+}
+
+// splitGraphRef splits a graph ref into the graph ID and variant name.
+func splitGraphRef(ref string) (string, string, error) {
+	graphID, variant, ok := strings.Cut(ref, "@")
+	if !ok || graphID == "" || variant == "" {
+		return "", "", fmt.Errorf("invalid graph ref %q, expected <graph-name>@<variant-name>", ref)
+	}
+	return graphID, variant, nil
+}
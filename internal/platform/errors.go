@@ -0,0 +1,21 @@
+package platform
+
+import "errors"
+
+// ErrNotFound is returned when mutating an object that does not exist.
+var ErrNotFound = errors.New("not found")
+
+// OperationError is returned when the Apollo Platform API rejected an
+// operation, for example because of invalid input or composition errors.
+type OperationError struct {
+	Message string
+}
+
+func (e *OperationError) Error() string {
+	return e.Message
+}
+
+func IsOperationError(err error) bool {
+	var e *OperationError
+	return errors.As(err, &e)
+}
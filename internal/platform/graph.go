@@ -0,0 +1,136 @@
+package platform
+
+import (
+	"context"
+
+	"github.com/hasura/go-graphql-client"
+)
+
+// GraphType is the type of graph, it determines how the supergraph is run.
+type GraphType string
+
+const (
+	GraphTypeClassic              GraphType = "CLASSIC"
+	GraphTypeCloudSupergraph      GraphType = "CLOUD_SUPERGRAPH"
+	GraphTypeSelfHostedSupergraph GraphType = "SELF_HOSTED_SUPERGRAPH"
+)
+
+type Graph struct {
+	ID          string    `graphql:"id"`
+	Title       string    `graphql:"title"`
+	Description *string   `graphql:"description"`
+	GraphType   GraphType `graphql:"graphType"`
+	Account     struct {
+		ID string `graphql:"id"`
+	} `graphql:"account"`
+}
+
+type CreateGraphOptions struct {
+	ID             string
+	Title          string
+	GraphType      GraphType
+	OrganizationID string
+}
+
+type UpdateGraphOptions struct {
+	ID          string
+	Title       string
+	Description string
+}
+
+// GetGraph returns the graph with the given ID, or nil when it does not
+// exist.
+func (c *Client) GetGraph(ctx context.Context, id string) (*Graph, error) {
+	var q struct {
+		Graph *Graph `graphql:"graph(id: $id)"`
+	}
+
+	err := c.gql.Query(ctx, &q, map[string]any{
+		"id": graphql.ID(id),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return q.Graph, nil
+}
+
+func (c *Client) CreateGraph(ctx context.Context, opts *CreateGraphOptions) (*Graph, error) {
+	var m struct {
+		Account *struct {
+			CreateGraph struct {
+				Typename string `graphql:"__typename"`
+				Graph    Graph  `graphql:"... on Graph"`
+				Error    struct {
+					Message string `graphql:"message"`
+				} `graphql:"... on GraphCreationError"`
+			} `graphql:"createGraph(graphType: $graphType, hiddenFromUninvitedNonAdmin: false, id: $id, title: $title)"`
+		} `graphql:"account(id: $organizationId)"`
+	}
+
+	err := c.gql.Mutate(ctx, &m, map[string]any{
+		"organizationId": graphql.ID(opts.OrganizationID),
+		"graphType":      opts.GraphType,
+		"id":             graphql.ID(opts.ID),
+		"title":          opts.Title,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Account == nil {
+		return nil, &OperationError{Message: "organization " + opts.OrganizationID + " not found"}
+	}
+	if m.Account.CreateGraph.Typename != "Graph" {
+		return nil, &OperationError{Message: m.Account.CreateGraph.Error.Message}
+	}
+
+	return &m.Account.CreateGraph.Graph, nil
+}
+
+func (c *Client) UpdateGraph(ctx context.Context, opts *UpdateGraphOptions) error {
+	var m struct {
+		Graph *struct {
+			UpdateTitle struct {
+				ID string `graphql:"id"`
+			} `graphql:"updateTitle(title: $title)"`
+			UpdateDescription struct {
+				ID string `graphql:"id"`
+			} `graphql:"updateDescription(description: $description)"`
+		} `graphql:"graph(id: $id)"`
+	}
+
+	err := c.gql.Mutate(ctx, &m, map[string]any{
+		"id":          graphql.ID(opts.ID),
+		"title":       opts.Title,
+		"description": opts.Description,
+	})
+	if err != nil {
+		return err
+	}
+
+	if m.Graph == nil {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (c *Client) DeleteGraph(ctx context.Context, id string) error {
+	var m struct {
+		Graph *struct {
+			Delete *bool `graphql:"delete"`
+		} `graphql:"graph(id: $id)"`
+	}
+
+	err := c.gql.Mutate(ctx, &m, map[string]any{
+		"id": graphql.ID(id),
+	})
+	if err != nil {
+		return err
+	}
+
+	if m.Graph == nil {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hasura/go-graphql-client"
+)
+
+type SourceLocation struct {
+	Line   int `graphql:"line"`
+	Column int `graphql:"column"`
+}
+
+type CompositionError struct {
+	Message   string           `graphql:"message"`
+	Code      *string          `graphql:"code"`
+	Locations []SourceLocation `graphql:"locations"`
+}
+
+type launch struct {
+	ID        string    `graphql:"id"`
+	Status    string    `graphql:"status"`
+	CreatedAt time.Time `graphql:"createdAt"`
+	Build     *struct {
+		Result *struct {
+			Typename string `graphql:"__typename"`
+			Success  struct {
+				CoreSchema struct {
+					CoreDocument string `graphql:"coreDocument"`
+					CoreHash     string `graphql:"coreHash"`
+				} `graphql:"coreSchema"`
+			} `graphql:"... on BuildSuccess"`
+			Failure struct {
+				ErrorMessages []CompositionError `graphql:"errorMessages"`
+			} `graphql:"... on BuildFailure"`
+		} `graphql:"result"`
+		Input struct {
+			Composition struct {
+				SubGraphs []struct {
+					Name string `graphql:"name"`
+				} `graphql:"subgraphs"`
+			} `graphql:"... on CompositionBuildInput"`
+		} `graphql:"input"`
+	} `graphql:"build"`
+}
+
+// SchemaBuild is the result of composing the subgraphs of a variant in a
+// launch. The supergraph SDL and hash are empty when composition failed or
+// the launch is still building.
+type SchemaBuild struct {
+	LaunchID      string
+	Status        string
+	CreatedAt     time.Time
+	SupergraphSDL string
+	Hash          string
+	SubGraphs     []string
+	Errors        []CompositionError
+	// Pending is set while the launch is still building, the build has no
+	// result yet.
+	Pending bool
+}
+
+// GetLatestSchemaBuild returns the build of the latest launch of the variant
+// of the given graph ref, or nil when the variant was never launched.
+func (c *Client) GetLatestSchemaBuild(ctx context.Context, ref string) (*SchemaBuild, error) {
+	var q struct {
+		Variant *struct {
+			Typename string `graphql:"__typename"`
+			Variant  struct {
+				LatestLaunch *launch `graphql:"latestLaunch"`
+			} `graphql:"... on GraphVariant"`
+			Error struct {
+				Message string `graphql:"message"`
+			} `graphql:"... on InvalidRefFormat"`
+		} `graphql:"variant(ref: $ref)"`
+	}
+
+	err := c.gql.Query(ctx, &q, map[string]any{
+		"ref": graphql.ID(ref),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if q.Variant == nil {
+		return nil, nil
+	}
+	if q.Variant.Typename != "GraphVariant" {
+		return nil, &OperationError{Message: q.Variant.Error.Message}
+	}
+
+	l := q.Variant.Variant.LatestLaunch
+	if l == nil || l.Build == nil {
+		return nil, nil
+	}
+
+	build := &SchemaBuild{
+		LaunchID:  l.ID,
+		Status:    l.Status,
+		CreatedAt: l.CreatedAt,
+		SubGraphs: []string{},
+	}
+	for _, sg := range l.Build.Input.Composition.SubGraphs {
+		build.SubGraphs = append(build.SubGraphs, sg.Name)
+	}
+	r := l.Build.Result
+	if r == nil {
+		build.Pending = true
+		return build, nil
+	}
+	switch r.Typename {
+	case "BuildSuccess":
+		build.SupergraphSDL = r.Success.CoreSchema.CoreDocument
+		build.Hash = r.Success.CoreSchema.CoreHash
+	case "BuildFailure":
+		build.Errors = r.Failure.ErrorMessages
+	default:
+		return nil, fmt.Errorf("unexpected build result %s of launch %s", r.Typename, l.ID)
+	}
+
+	return build, nil
+}
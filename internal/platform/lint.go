@@ -0,0 +1,47 @@
+package platform
+
+import (
+	"context"
+
+	"github.com/hasura/go-graphql-client"
+)
+
+// LintDiagnostic is a violation of one of the linter rules of a graph.
+type LintDiagnostic struct {
+	Rule       string `graphql:"rule"`
+	Level      string `graphql:"level"`
+	Coordinate string `graphql:"coordinate"`
+	Message    string `graphql:"message"`
+}
+
+// LintSchema lints the SDL with the linter configuration of the graph of the
+// given graph ref. When baseSDL is set only the violations introduced compared
+// to it are returned.
+func (c *Client) LintSchema(ctx context.Context, ref, sdl string, baseSDL *string) ([]LintDiagnostic, error) {
+	graphID, _, err := splitGraphRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var m struct {
+		Graph *struct {
+			LintSchema struct {
+				Diagnostics []LintDiagnostic `graphql:"diagnostics"`
+			} `graphql:"lintSchema(sdl: $sdl, baseSdl: $baseSdl)"`
+		} `graphql:"graph(id: $graphId)"`
+	}
+
+	err = c.gql.Mutate(ctx, &m, map[string]any{
+		"graphId": graphql.ID(graphID),
+		"sdl":     sdl,
+		"baseSdl": baseSDL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Graph == nil {
+		return nil, &OperationError{Message: "graph " + graphID + " not found"}
+	}
+	return m.Graph.LintSchema.Diagnostics, nil
+}
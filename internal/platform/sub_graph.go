@@ -0,0 +1,135 @@
+package platform
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/hasura/go-graphql-client"
+)
+
+type SubGraph struct {
+	Name                string    `graphql:"name"`
+	URL                 *string   `graphql:"url"`
+	Revision            string    `graphql:"revision"`
+	CreatedAt           time.Time `graphql:"createdAt"`
+	UpdatedAt           time.Time `graphql:"updatedAt"`
+	ActivePartialSchema struct {
+		Sdl string `graphql:"sdl"`
+	} `graphql:"activePartialSchema @include(if: $includeSchema)"`
+}
+
+// ListSubGraphs returns the subgraphs published to the variant of the given
+// graph ref, the SDL of the subgraphs is only fetched when includeSchema is
+// set. A variant that does not exist has no subgraphs.
+func (c *Client) ListSubGraphs(ctx context.Context, ref string, includeSchema bool) ([]SubGraph, error) {
+	var q struct {
+		Variant *struct {
+			Typename string `graphql:"__typename"`
+			Variant  struct {
+				SubGraphs []SubGraph `graphql:"subgraphs"`
+			} `graphql:"... on GraphVariant"`
+			Error struct {
+				Message string `graphql:"message"`
+			} `graphql:"... on InvalidRefFormat"`
+		} `graphql:"variant(ref: $ref)"`
+	}
+
+	err := c.gql.Query(ctx, &q, map[string]any{
+		"ref":           graphql.ID(ref),
+		"includeSchema": includeSchema,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if q.Variant == nil {
+		return []SubGraph{}, nil
+	}
+	if q.Variant.Typename != "GraphVariant" {
+		return nil, &OperationError{Message: q.Variant.Error.Message}
+	}
+
+	return q.Variant.Variant.SubGraphs, nil
+}
+
+// PartialSchemaInput is the SDL of a subgraph as sent to the API.
+type PartialSchemaInput struct {
+	Sdl string `json:"sdl"`
+}
+
+type PublishSubGraphOptions struct {
+	GraphRef string
+	Name     string
+	URL      *string
+	Schema   string
+}
+
+// PublishResult is the result of publishing a subgraph, Errors holds the
+// composition errors of the launch triggered by the publish.
+type PublishResult struct {
+	WasCreated     bool
+	UpdatedGateway bool
+	LaunchID       string
+	LaunchURL      string
+	Errors         []CompositionError
+}
+
+// PublishSubGraph publishes the subgraph to the variant of the given graph
+// ref. Composition errors do not fail the publish, they are returned in the
+// result.
+func (c *Client) PublishSubGraph(ctx context.Context, opts *PublishSubGraphOptions) (*PublishResult, error) {
+	graphID, variant, err := splitGraphRef(opts.GraphRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var m struct {
+		Graph *struct {
+			PublishSubgraph *struct {
+				Errors         []CompositionError `graphql:"errors"`
+				WasCreated     bool               `graphql:"wasCreated"`
+				UpdatedGateway bool               `graphql:"updatedGateway"`
+				LaunchURL      *string            `graphql:"launchUrl"`
+				Launch         *struct {
+					ID string `graphql:"id"`
+				} `graphql:"launch"`
+			} `graphql:"publishSubgraph(graphVariant: $variant, name: $name, url: $url, revision: $revision, activePartialSchema: $schema)"`
+		} `graphql:"graph(id: $graphId)"`
+	}
+
+	sum := sha256.Sum256([]byte(opts.Schema))
+	err = c.gql.Mutate(ctx, &m, map[string]any{
+		"graphId":  graphql.ID(graphID),
+		"variant":  variant,
+		"name":     opts.Name,
+		"url":      opts.URL,
+		"revision": hex.EncodeToString(sum[:])[:12],
+		"schema":   PartialSchemaInput{Sdl: opts.Schema},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Graph == nil {
+		return nil, &OperationError{Message: "graph " + graphID + " not found"}
+	}
+	if m.Graph.PublishSubgraph == nil {
+		return nil, &OperationError{Message: "unable to publish subgraph " + opts.Name}
+	}
+
+	p := m.Graph.PublishSubgraph
+	result := &PublishResult{
+		WasCreated:     p.WasCreated,
+		UpdatedGateway: p.UpdatedGateway,
+		Errors:         p.Errors,
+	}
+	if p.LaunchURL != nil {
+		result.LaunchURL = *p.LaunchURL
+	}
+	if p.Launch != nil {
+		result.LaunchID = p.Launch.ID
+	}
+	return result, nil
+}
@@ -0,0 +1,136 @@
+package platform
+
+import (
+	"context"
+
+	"github.com/hasura/go-graphql-client"
+)
+
+type Variant struct {
+	ID             string  `graphql:"id"`
+	Name           string  `graphql:"name"`
+	URL            *string `graphql:"url"`
+	IsPublic       bool    `graphql:"isPublic"`
+	DefaultHeaders *string `graphql:"defaultHeaders"`
+}
+
+type UpdateVariantOptions struct {
+	GraphID        string
+	Name           string
+	URL            *string
+	IsPublic       bool
+	DefaultHeaders *string
+}
+
+// GetVariant returns the variant for the given graph ref, or nil when it does
+// not exist.
+func (c *Client) GetVariant(ctx context.Context, ref string) (*Variant, error) {
+	var q struct {
+		Variant *struct {
+			Typename string  `graphql:"__typename"`
+			Variant  Variant `graphql:"... on GraphVariant"`
+			Error    struct {
+				Message string `graphql:"message"`
+			} `graphql:"... on InvalidRefFormat"`
+		} `graphql:"variant(ref: $ref)"`
+	}
+
+	err := c.gql.Query(ctx, &q, map[string]any{
+		"ref": graphql.ID(ref),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if q.Variant == nil {
+		return nil, nil
+	}
+	if q.Variant.Typename != "GraphVariant" {
+		return nil, &OperationError{Message: q.Variant.Error.Message}
+	}
+
+	return &q.Variant.Variant, nil
+}
+
+// CreateVariant creates an empty variant, use UpdateVariant to configure it.
+func (c *Client) CreateVariant(ctx context.Context, graphID, name string) error {
+	var m struct {
+		Graph *struct {
+			NewVariant *struct {
+				ID string `graphql:"id"`
+			} `graphql:"newVariant(name: $name)"`
+		} `graphql:"graph(id: $graphId)"`
+	}
+
+	err := c.gql.Mutate(ctx, &m, map[string]any{
+		"graphId": graphql.ID(graphID),
+		"name":    name,
+	})
+	if err != nil {
+		return err
+	}
+
+	if m.Graph == nil {
+		return &OperationError{Message: "graph " + graphID + " not found"}
+	}
+	return nil
+}
+
+func (c *Client) UpdateVariant(ctx context.Context, opts *UpdateVariantOptions) error {
+	var m struct {
+		Graph *struct {
+			Variant *struct {
+				UpdateURL struct {
+					ID string `graphql:"id"`
+				} `graphql:"updateURL(url: $url)"`
+				UpdateVariantIsPublic struct {
+					ID string `graphql:"id"`
+				} `graphql:"updateVariantIsPublic(isPublic: $isPublic)"`
+				UpdateDefaultHeaders struct {
+					ID string `graphql:"id"`
+				} `graphql:"updateDefaultHeaders(defaultHeaders: $defaultHeaders)"`
+			} `graphql:"variant(name: $name)"`
+		} `graphql:"graph(id: $graphId)"`
+	}
+
+	err := c.gql.Mutate(ctx, &m, map[string]any{
+		"graphId":        graphql.ID(opts.GraphID),
+		"name":           opts.Name,
+		"url":            opts.URL,
+		"isPublic":       opts.IsPublic,
+		"defaultHeaders": opts.DefaultHeaders,
+	})
+	if err != nil {
+		return err
+	}
+
+	if m.Graph == nil || m.Graph.Variant == nil {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (c *Client) DeleteVariant(ctx context.Context, graphID, name string) error {
+	var m struct {
+		Graph *struct {
+			Variant *struct {
+				Delete struct {
+					Deleted bool `graphql:"deleted"`
+				} `graphql:"delete"`
+			} `graphql:"variant(name: $name)"`
+		} `graphql:"graph(id: $graphId)"`
+	}
+
+	err := c.gql.Mutate(ctx, &m, map[string]any{
+		"graphId": graphql.ID(graphID),
+		"name":    name,
+	})
+	if err != nil {
+		return err
+	}
+
+	if m.Graph == nil || m.Graph.Variant == nil {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labd/apollostudio-go-sdk/apollostudio"
+	"github.com/labd/terraform-provider-apollostudio/internal/platform"
+)
+
+// clientCache creates Apollo Studio clients per graph ref. The SDK binds a
+// client to a single graph ref, so resources that override the provider
+// graph_ref get their own client, which is shared by every resource using
+// the same ref. Operations the SDK does not support go through the platform
+// client, which is not bound to a graph ref. Operations without their own
+// timeouts use the default timeout of the provider.
+type clientCache struct {
+	key      string
+	graphRef string
+	opts     []apollostudio.ClientOpt
+	platform *platform.Client
+	timeout  time.Duration
+
+	mu      sync.Mutex
+	clients map[string]*apollostudio.Client
+}
+
+func newClientCache(key, graphRef string, opts ...apollostudio.ClientOpt) *clientCache {
+	return &clientCache{
+		key:      key,
+		graphRef: graphRef,
+		opts:     opts,
+		timeout:  defaultTimeout,
+		clients:  map[string]*apollostudio.Client{},
+	}
+}
+
+// resolve returns the graph ref to use, falling back to the provider graph
+// ref when ref is empty.
+func (c *clientCache) resolve(ref string) (string, error) {
+	if ref != "" {
+		return ref, nil
+	}
+	if c.graphRef == "" {
+		return "", errors.New(
+			"no graph ref configured, please set the graph_ref attribute on the resource or the provider",
+		)
+	}
+	return c.graphRef, nil
+}
+
+// withVariant returns the graph ref with its variant replaced, or the graph
+// ref itself when variant is empty.
+func withVariant(ref, variant string) string {
+	if variant == "" {
+		return ref
+	}
+	graphID, _, _ := strings.Cut(ref, "@")
+	return graphID + "@" + variant
+}
+
+// get returns the client for the given graph ref, or for the provider graph
+// ref when ref is empty. The resolved graph ref is returned with the client.
+func (c *clientCache) get(ref string) (*apollostudio.Client, string, error) {
+	ref, err := c.resolve(ref)
+	if err != nil {
+		return nil, "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if client, ok := c.clients[ref]; ok {
+		return client, ref, nil
+	}
+
+	client, err := apollostudio.NewClient(c.key, ref, c.opts...)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to create client for graph ref %s: %w", ref, err)
+	}
+
+	c.clients[ref] = client
+	return client, ref, nil
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/labd/terraform-provider-apollostudio/internal/platform"
+)
+
+// compositionDiagnostics reports every composition error as its own
+// diagnostic with the error code in the summary and the message and source
+// locations in the detail. The diagnostics are errors when fail is set and
+// warnings otherwise.
+func compositionDiagnostics(errs []platform.CompositionError, fail bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, e := range errs {
+		summary := "Composition error"
+		if e.Code != nil && *e.Code != "" {
+			summary = fmt.Sprintf("Composition error: %s", *e.Code)
+		}
+
+		detail := e.Message
+		if len(e.Locations) > 0 {
+			locations := make([]string, len(e.Locations))
+			for i, l := range e.Locations {
+				locations[i] = fmt.Sprintf("line %d, column %d", l.Line, l.Column)
+			}
+			detail += "\n\nLocation: " + strings.Join(locations, "; ")
+		}
+
+		if fail {
+			diags.AddError(summary, detail)
+		} else {
+			diags.AddWarning(summary, detail)
+		}
+	}
+	return diags
+}
@@ -0,0 +1,290 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labd/terraform-provider-apollostudio/internal/platform"
+)
+
+var (
+	_ resource.Resource                = &GraphAPIKeyResource{}
+	_ resource.ResourceWithConfigure   = &GraphAPIKeyResource{}
+	_ resource.ResourceWithImportState = &GraphAPIKeyResource{}
+)
+
+func NewGraphAPIKeyResource() resource.Resource {
+	return &GraphAPIKeyResource{}
+}
+
+// GraphAPIKeyResource defines the resource implementation.
+type GraphAPIKeyResource struct {
+	client  *platform.Client
+	timeout time.Duration
+}
+
+// GraphAPIKeyResourceModel describes the resource data model.
+type GraphAPIKeyResourceModel struct {
+	ID        types.String   `tfsdk:"id"`
+	GraphID   types.String   `tfsdk:"graph_id"`
+	Name      types.String   `tfsdk:"name"`
+	Role      types.String   `tfsdk:"role"`
+	Token     types.String   `tfsdk:"token"`
+	CreatedAt types.String   `tfsdk:"created_at"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *GraphAPIKeyResource) Metadata(
+	_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_graph_api_key"
+}
+
+func (r *GraphAPIKeyResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource is used to manage graph API keys, for example to authenticate " +
+			"routers. The token is only available after creating the key, rotating the key is done by " +
+			"replacing the resource. More information about API keys can be found " +
+			"[here](https://www.apollographql.com/docs/graphos/api-keys/).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the API key",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"graph_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the graph the API key belongs to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the API key",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "The role of the API key, one of `GRAPH_ADMIN`, `CONTRIBUTOR`, " +
+					"`DOCUMENTER` or `OBSERVER`. Defaults to `GRAPH_ADMIN`",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(string(platform.UserPermissionGraphAdmin)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(platform.UserPermissionGraphAdmin),
+						string(platform.UserPermissionContributor),
+						string(platform.UserPermissionDocumenter),
+						string(platform.UserPermissionObserver),
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The API key token, only available when the key is created by Terraform",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The creation date of the API key",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *GraphAPIKeyResource) Configure(
+	_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*clientCache)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *clientCache, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = clients.platform
+	r.timeout = clients.timeout
+}
+
+func (r *GraphAPIKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan GraphAPIKeyResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diagErr := plan.Timeouts.Create(ctx, r.timeout)
+	if diagErr.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	key, err := r.client.CreateAPIKey(
+		ctx, &platform.CreateAPIKeyOptions{
+			GraphID: plan.GraphID.ValueString(),
+			Name:    plan.Name.ValueString(),
+			Role:    platform.UserPermission(plan.Role.ValueString()),
+		},
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create API key, got error: %s", err))
+		return
+	}
+
+	plan.ID = types.StringValue(key.ID)
+	plan.Token = types.StringValue(key.Token)
+	plan.CreatedAt = types.StringValue(key.CreatedAt.Format(time.RFC850))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *GraphAPIKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state GraphAPIKeyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diagErr := state.Timeouts.Read(ctx, r.timeout)
+	if diagErr.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	key, err := r.client.GetAPIKey(ctx, state.GraphID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read API key, got error: %s", err))
+		return
+	}
+
+	if key == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// the token is only returned when creating the key, so it is kept as is,
+	// just like the name when the API does not return one
+	if key.KeyName != nil {
+		state.Name = types.StringValue(*key.KeyName)
+	}
+	state.Role = types.StringValue(string(key.Role))
+	state.CreatedAt = types.StringValue(key.CreatedAt.Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *GraphAPIKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan GraphAPIKeyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diagErr := plan.Timeouts.Update(ctx, r.timeout)
+	if diagErr.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// only the name can change in place, every other attribute replaces the key
+	err := r.client.RenameAPIKey(ctx, plan.GraphID.ValueString(), plan.ID.ValueString(), plan.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rename API key, got error: %s", err))
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *GraphAPIKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state GraphAPIKeyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diagErr := state.Timeouts.Delete(ctx, r.timeout)
+	if diagErr.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteAPIKey(ctx, state.GraphID.ValueString(), state.ID.ValueString())
+	if err != nil && !errors.Is(err, platform.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete API key, got error: %s", err))
+		return
+	}
+}
+
+func (r *GraphAPIKeyResource) ImportState(
+	ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse,
+) {
+	// the import ID is `<graph-id>/<key-id>`, the token is not available
+	// after importing
+	graphID, id, found := strings.Cut(req.ID, "/")
+	if !found || graphID == "" || id == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <graph-id>/<key-id>. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("graph_id"), graphID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/labd/terraform-provider-apollostudio/internal/acctest"
+	"github.com/labd/terraform-provider-apollostudio/internal/utils"
+)
+
+func TestAccGraphAPIKey_basic(t *testing.T) {
+	graphID, _, _ := strings.Cut(os.Getenv("APOLLO_GRAPH_REF"), "@")
+	n := "apollostudio_graph_api_key.router"
+
+	resource.Test(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			CheckDestroy:             testAccCheckGraphAPIKeyResourceDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccGraphAPIKeyConfig("router", graphID, "terraform-acc", "OBSERVER"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrSet(n, "id"),
+						resource.TestCheckResourceAttrSet(n, "token"),
+						resource.TestCheckResourceAttr(n, "name", "terraform-acc"),
+						resource.TestCheckResourceAttr(n, "role", "OBSERVER"),
+					),
+				},
+				{
+					Config: testAccGraphAPIKeyConfig("router", graphID, "terraform-acc-renamed", "OBSERVER"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrSet(n, "token"),
+						resource.TestCheckResourceAttr(n, "name", "terraform-acc-renamed"),
+					),
+				},
+				{
+					ResourceName:      n,
+					ImportState:       true,
+					ImportStateVerify: true,
+					ImportStateIdFunc: func(s *terraform.State) (string, error) {
+						rs, ok := s.RootModule().Resources[n]
+						if !ok {
+							return "", fmt.Errorf("not found: %s", n)
+						}
+						return graphID + "/" + rs.Primary.ID, nil
+					},
+					ImportStateVerifyIgnore: []string{"timeouts", "token"},
+				},
+			},
+		},
+	)
+}
+
+func testAccGraphAPIKeyConfig(res, graphID, name, role string) string {
+	return utils.HCLTemplate(
+		`
+		resource "apollostudio_graph_api_key" {{ .res }} {
+		  graph_id = "{{ .graphID }}"
+		  name = "{{ .name }}"
+		  role = "{{ .role }}"
+		}
+		`,
+		map[string]any{
+			"res":     res,
+			"graphID": graphID,
+			"name":    name,
+			"role":    role,
+		},
+	)
+}
+
+func testAccCheckGraphAPIKeyResourceDestroy(s *terraform.State) error {
+	client := acctest.GetPlatformClient()
+	ctx := context.Background()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "apollostudio_graph_api_key" {
+			continue
+		}
+		key, err := client.GetAPIKey(ctx, rs.Primary.Attributes["graph_id"], rs.Primary.ID)
+		if err == nil && key != nil {
+			return fmt.Errorf("API key (%s) still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
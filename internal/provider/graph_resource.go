@@ -0,0 +1,306 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labd/terraform-provider-apollostudio/internal/platform"
+)
+
+var (
+	_ resource.Resource                = &GraphResource{}
+	_ resource.ResourceWithConfigure   = &GraphResource{}
+	_ resource.ResourceWithImportState = &GraphResource{}
+)
+
+func NewGraphResource() resource.Resource {
+	return &GraphResource{}
+}
+
+// GraphResource defines the resource implementation.
+type GraphResource struct {
+	client  *platform.Client
+	timeout time.Duration
+}
+
+// GraphResourceModel describes the resource data model.
+type GraphResourceModel struct {
+	ID             types.String   `tfsdk:"id"`
+	GraphID        types.String   `tfsdk:"graph_id"`
+	Title          types.String   `tfsdk:"title"`
+	Description    types.String   `tfsdk:"description"`
+	GraphType      types.String   `tfsdk:"graph_type"`
+	OrganizationID types.String   `tfsdk:"organization_id"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *GraphResource) Metadata(
+	_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_graph"
+}
+
+func (r *GraphResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource is used to manage graphs in Apollo Studio. " +
+			"More information about graphs can be found " +
+			"[here](https://www.apollographql.com/docs/graphos/graphs/).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the graph",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"graph_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the graph, used as the first part of the graph ref",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 64),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[a-zA-Z0-9_-]+$`),
+						"should only contain letters, numbers, underscores and dashes",
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "The title of the graph",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The description of the graph",
+				Optional:            true,
+			},
+			"graph_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the graph, one of `SELF_HOSTED_SUPERGRAPH`, " +
+					"`CLOUD_SUPERGRAPH` or `CLASSIC`. Defaults to `SELF_HOSTED_SUPERGRAPH`",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(string(platform.GraphTypeSelfHostedSupergraph)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(platform.GraphTypeSelfHostedSupergraph),
+						string(platform.GraphTypeCloudSupergraph),
+						string(platform.GraphTypeClassic),
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization the graph belongs to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *GraphResource) Configure(
+	_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*clientCache)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *clientCache, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = clients.platform
+	r.timeout = clients.timeout
+}
+
+func (r *GraphResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan GraphResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diagErr := plan.Timeouts.Create(ctx, r.timeout)
+	if diagErr.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	graph, err := r.client.CreateGraph(
+		ctx, &platform.CreateGraphOptions{
+			ID:             plan.GraphID.ValueString(),
+			Title:          plan.Title.ValueString(),
+			GraphType:      platform.GraphType(plan.GraphType.ValueString()),
+			OrganizationID: plan.OrganizationID.ValueString(),
+		},
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create graph, got error: %s", err))
+		return
+	}
+
+	// save the graph before setting its description, so a failed update taints
+	// the graph instead of leaving it behind outside of the state
+	plan.ID = types.StringValue(graph.ID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Description.ValueString() != "" {
+		err = r.client.UpdateGraph(
+			ctx, &platform.UpdateGraphOptions{
+				ID:          plan.GraphID.ValueString(),
+				Title:       plan.Title.ValueString(),
+				Description: plan.Description.ValueString(),
+			},
+		)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error", fmt.Sprintf("Unable to set description of graph, got error: %s", err),
+			)
+			return
+		}
+	}
+}
+
+func (r *GraphResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state GraphResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diagErr := state.Timeouts.Read(ctx, r.timeout)
+	if diagErr.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	graph, err := r.client.GetGraph(ctx, state.GraphID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read graph, got error: %s", err))
+		return
+	}
+
+	if graph == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ID = types.StringValue(graph.ID)
+	state.GraphID = types.StringValue(graph.ID)
+	state.Title = types.StringValue(graph.Title)
+	state.GraphType = types.StringValue(string(graph.GraphType))
+	state.OrganizationID = types.StringValue(graph.Account.ID)
+	if graph.Description != nil && *graph.Description != "" {
+		state.Description = types.StringValue(*graph.Description)
+	} else {
+		state.Description = types.StringNull()
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *GraphResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan GraphResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diagErr := plan.Timeouts.Update(ctx, r.timeout)
+	if diagErr.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	err := r.client.UpdateGraph(
+		ctx, &platform.UpdateGraphOptions{
+			ID:          plan.GraphID.ValueString(),
+			Title:       plan.Title.ValueString(),
+			Description: plan.Description.ValueString(),
+		},
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update graph, got error: %s", err))
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *GraphResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state GraphResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diagErr := state.Timeouts.Delete(ctx, r.timeout)
+	if diagErr.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteGraph(ctx, state.GraphID.ValueString())
+	if err != nil && !errors.Is(err, platform.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete graph, got error: %s", err))
+		return
+	}
+}
+
+func (r *GraphResource) ImportState(
+	ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("graph_id"), req, resp)
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/labd/terraform-provider-apollostudio/internal/acctest"
+	"github.com/labd/terraform-provider-apollostudio/internal/utils"
+)
+
+func TestAccGraph_basic(t *testing.T) {
+	organizationID := os.Getenv("APOLLO_ORGANIZATION_ID")
+	id := "terraform-acc-graph"
+	n := "apollostudio_graph.test"
+
+	resource.Test(
+		t, resource.TestCase{
+			PreCheck: func() {
+				testAccPreCheck(t)
+				if organizationID == "" {
+					t.Skip("APOLLO_ORGANIZATION_ID must be set to run graph acceptance tests")
+				}
+			},
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			CheckDestroy:             testAccCheckGraphResourceDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccGraphConfig("test", id, "Acceptance test", "", organizationID),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(n, "id", id),
+						resource.TestCheckResourceAttr(n, "graph_id", id),
+						resource.TestCheckResourceAttr(n, "title", "Acceptance test"),
+						resource.TestCheckNoResourceAttr(n, "description"),
+						resource.TestCheckResourceAttr(n, "graph_type", "SELF_HOSTED_SUPERGRAPH"),
+						resource.TestCheckResourceAttr(n, "organization_id", organizationID),
+					),
+				},
+				{
+					Config: testAccGraphConfig(
+						"test", id, "Acceptance test updated", "Managed by Terraform", organizationID,
+					),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(n, "id", id),
+						resource.TestCheckResourceAttr(n, "title", "Acceptance test updated"),
+						resource.TestCheckResourceAttr(n, "description", "Managed by Terraform"),
+					),
+				},
+				{
+					ResourceName:            n,
+					ImportState:             true,
+					ImportStateId:           id,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"timeouts"},
+				},
+			},
+		},
+	)
+}
+
+func testAccGraphConfig(res, id, title, description, organizationID string) string {
+	return utils.HCLTemplate(
+		`
+		resource "apollostudio_graph" {{ .res }} {
+		  graph_id = "{{ .id }}"
+		  title = "{{ .title }}"
+		  {{ if .description }}description = "{{ .description }}"{{ end }}
+		  organization_id = "{{ .organizationID }}"
+		}
+		`,
+		map[string]any{
+			"res":            res,
+			"id":             id,
+			"title":          title,
+			"description":    description,
+			"organizationID": organizationID,
+		},
+	)
+}
+
+func testAccCheckGraphResourceDestroy(s *terraform.State) error {
+	client := acctest.GetPlatformClient()
+	ctx := context.Background()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "apollostudio_graph" {
+			continue
+		}
+		graph, err := client.GetGraph(ctx, rs.Primary.ID)
+		if err == nil && graph != nil {
+			return fmt.Errorf("graph (%s) still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
@@ -2,9 +2,12 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -12,15 +15,29 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/labd/apollostudio-go-sdk/apollostudio"
+	"github.com/labd/terraform-provider-apollostudio/internal/platform"
+	"github.com/labd/terraform-provider-apollostudio/internal/utils"
+	"net/url"
 	"os"
 	"regexp"
 	"time"
 )
 
 var (
-	defaultRetryMax = 10
-	defaultTimeout  = 2 * time.Minute
-	retryTimeout    = 5 * time.Second
+	defaultRetryMax        = 10
+	defaultRetryMinBackoff = 1 * time.Second
+	defaultRetryMaxBackoff = 30 * time.Second
+	defaultTimeout         = 2 * time.Minute
+
+	graphRefRegexp    = regexp.MustCompilePOSIX(`^[a-zA-Z0-9_-]+@[a-zA-Z0-9_-]+$`)
+	graphRefValidator = stringvalidator.RegexMatches(
+		graphRefRegexp,
+		"should be in the format of <graph-name>@<variant-name>",
+	)
+	durationValidator = stringvalidator.RegexMatches(
+		regexp.MustCompile(`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`),
+		"should be a duration such as \"30s\" or \"168h\"",
+	)
 
 	_ provider.Provider = &ApolloStudioProvider{}
 )
@@ -37,8 +54,15 @@ type ApolloStudioProvider struct {
 
 // ApolloStudioProviderModel describes the provider data model.
 type ApolloStudioProviderModel struct {
-	ApiKey   types.String `tfsdk:"api_key"`
-	GraphRef types.String `tfsdk:"graph_ref"`
+	ApiKey         types.String `tfsdk:"api_key"`
+	GraphRef       types.String `tfsdk:"graph_ref"`
+	Endpoint       types.String `tfsdk:"endpoint"`
+	MaxRetries     types.Int64  `tfsdk:"max_retries"`
+	MinBackoff     types.String `tfsdk:"min_backoff"`
+	MaxBackoff     types.String `tfsdk:"max_backoff"`
+	RequestTimeout types.String `tfsdk:"request_timeout"`
+	DefaultTimeout types.String `tfsdk:"default_timeout"`
+	MaxRPS         types.Int64  `tfsdk:"max_requests_per_second"`
 }
 
 func New(version string, debug bool) func() provider.Provider {
@@ -70,15 +94,80 @@ func (p *ApolloStudioProvider) Schema(_ context.Context, _ provider.SchemaReques
 				},
 			},
 			"graph_ref": schema.StringAttribute{
-				MarkdownDescription: "Apollo studio graph ref",
-				Optional:            true,
+				MarkdownDescription: "Apollo studio graph ref, used by resources and data sources " +
+					"that do not set their own `graph_ref`",
+				Optional: true,
+				Validators: []validator.String{
+					graphRefValidator,
+				},
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "Apollo Platform API endpoint, defaults to the `APOLLO_ENDPOINT` environment " +
+					"variable or the public Apollo Studio endpoint. Can be used to route traffic through a proxy",
+				Optional: true,
 				Validators: []validator.String{
 					stringvalidator.RegexMatches(
-						regexp.MustCompilePOSIX(`^[a-zA-Z0-9_-]+@[a-zA-Z0-9_-]+$`),
-						"should be in the format of <graph-name>@<variant-name>",
+						regexp.MustCompile(`^https?://[^/\s]+(/\S*)?$`),
+						"should be an absolute http(s) URL",
 					),
 				},
 			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf(
+					"The maximum number of retries of a failed request, defaults to %d", defaultRetryMax,
+				),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"min_backoff": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf(
+					"The time to wait before the first retry, the wait time doubles with every retry. "+
+						"Defaults to `%s`", defaultRetryMinBackoff,
+				),
+				Optional: true,
+				Validators: []validator.String{
+					durationValidator,
+				},
+			},
+			"max_backoff": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf(
+					"The maximum time to wait between retries, defaults to `%s`. Throttled requests wait as "+
+						"long as the `Retry-After` header of the response asks for, up to this maximum",
+					defaultRetryMaxBackoff,
+				),
+				Optional: true,
+				Validators: []validator.String{
+					durationValidator,
+				},
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "The timeout of a single HTTP request to Apollo Studio. By default requests " +
+					"are only limited by the timeout of the operation",
+				Optional: true,
+				Validators: []validator.String{
+					durationValidator,
+				},
+			},
+			"max_requests_per_second": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of requests per second to Apollo Studio, shared by all " +
+					"resources and data sources of the provider. By default requests are not throttled",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"default_timeout": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf(
+					"The timeout of resource and data source operations that do not set their own `timeouts`, "+
+						"defaults to `%s`", defaultTimeout,
+				),
+				Optional: true,
+				Validators: []validator.String{
+					durationValidator,
+				},
+			},
 		},
 	}
 }
@@ -107,6 +196,13 @@ func (p *ApolloStudioProvider) Configure(
 		ref = data.GraphRef.ValueString()
 	}
 
+	var endpoint string
+	if data.Endpoint.IsUnknown() || data.Endpoint.IsNull() {
+		endpoint = os.Getenv("APOLLO_ENDPOINT")
+	} else {
+		endpoint = data.Endpoint.ValueString()
+	}
+
 	if key == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_key"),
@@ -116,45 +212,124 @@ func (p *ApolloStudioProvider) Configure(
 		return
 	}
 
-	if ref == "" {
+	// the endpoint is validated here as well, as APOLLO_ENDPOINT does not go
+	// through the attribute validators
+	if endpoint == "" {
+		endpoint = platform.DefaultEndpoint
+	}
+	if u, err := url.Parse(endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("graph_ref"),
-			"Missing Apollo Studio Graph ref",
-			"Please set the graph_ref attribute or the APOLLO_GRAPH_REF environment variable",
+			path.Root("endpoint"),
+			"Invalid Apollo Studio endpoint",
+			fmt.Sprintf("Endpoint %q should be an absolute http(s) URL", endpoint),
+		)
+		return
+	}
+
+	retryPolicy := utils.RetryPolicy{
+		MaxRetries: defaultRetryMax,
+		MinBackoff: defaultRetryMinBackoff,
+		MaxBackoff: defaultRetryMaxBackoff,
+	}
+	if !data.MaxRetries.IsUnknown() && !data.MaxRetries.IsNull() {
+		retryPolicy.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+	retryPolicy.MinBackoff = durationAttribute(&resp.Diagnostics, "min_backoff", data.MinBackoff, retryPolicy.MinBackoff)
+	retryPolicy.MaxBackoff = durationAttribute(&resp.Diagnostics, "max_backoff", data.MaxBackoff, retryPolicy.MaxBackoff)
+	requestTimeout := durationAttribute(&resp.Diagnostics, "request_timeout", data.RequestTimeout, 0)
+	operationTimeout := durationAttribute(&resp.Diagnostics, "default_timeout", data.DefaultTimeout, defaultTimeout)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if retryPolicy.MinBackoff > retryPolicy.MaxBackoff {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_backoff"),
+			"Invalid retry backoff",
+			fmt.Sprintf(
+				"max_backoff (%s) should not be shorter than min_backoff (%s)",
+				retryPolicy.MaxBackoff, retryPolicy.MinBackoff,
+			),
 		)
 		return
 	}
 
 	retryClient := retryablehttp.NewClient()
-	retryClient.RetryMax = defaultRetryMax
+	retryClient.RetryMax = retryPolicy.MaxRetries
+	retryClient.RetryWaitMin = retryPolicy.MinBackoff
+	retryClient.RetryWaitMax = retryPolicy.MaxBackoff
+	retryClient.Backoff = retryPolicy.HTTPBackoff
+	retryClient.HTTPClient.Timeout = requestTimeout
+
+	// every attempt of the retry client waits for the rate limiter, so retries
+	// count towards the limit as well
+	if !data.MaxRPS.IsUnknown() && !data.MaxRPS.IsNull() {
+		limiter := utils.NewRateLimiter(int(data.MaxRPS.ValueInt64()))
+		retryClient.HTTPClient.Transport = utils.NewRateLimitedTransport(limiter, retryClient.HTTPClient.Transport)
+	}
+
+	httpClient := retryClient.StandardClient()
 
 	var clientOpts = []apollostudio.ClientOpt{
-		apollostudio.WithHttpClient(retryClient.StandardClient()),
+		apollostudio.WithHttpClient(httpClient),
 		apollostudio.WithDebug(p.debug),
+		apollostudio.WithUrl(endpoint),
+	}
+
+	clients := newClientCache(key, ref, clientOpts...)
+	clients.timeout = operationTimeout
+
+	clients.platform = platform.NewClient(endpoint, key, httpClient)
+
+	// validate the provider graph ref up front, resources that override the
+	// graph ref create their clients when they are used
+	if ref != "" {
+		if _, _, err := clients.get(ref); err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to create Apollo Studio client",
+				"Please check your API key and Graph ref",
+			)
+			return
+		}
 	}
 
-	client, err := apollostudio.NewClient(key, ref, clientOpts...)
+	resp.DataSourceData = clients
+	resp.ResourceData = clients
+}
+
+// durationAttribute parses the duration of a provider attribute, def is
+// returned when the attribute is not set.
+func durationAttribute(diags *diag.Diagnostics, name string, value types.String, def time.Duration) time.Duration {
+	if value.IsUnknown() || value.IsNull() {
+		return def
+	}
 
+	d, err := time.ParseDuration(value.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to create Apollo Studio client",
-			"Please check your API key and Graph ref",
+		diags.AddAttributeError(
+			path.Root(name),
+			"Invalid duration",
+			fmt.Sprintf("Unable to parse %s %q: %s", name, value.ValueString(), err),
 		)
-		return
+		return def
 	}
-
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	return d
 }
 
 func (p *ApolloStudioProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewValidationDataSource,
+		NewSubGraphsDataSource,
+		NewSubGraphDataSource,
+		NewSupergraphDataSource,
 	}
 }
 
 func (p *ApolloStudioProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewSubGraphResource,
+		NewGraphResource,
+		NewVariantResource,
+		NewGraphAPIKeyResource,
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/labd/terraform-provider-apollostudio/internal/acctest"
+	"github.com/labd/terraform-provider-apollostudio/internal/acctest/mockserver"
 )
 
 var testAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
@@ -23,6 +24,19 @@ var testAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServe
 	},
 }
 
+// testAccMockServer is the mock the acceptance tests run against, nil when
+// they run against the real API.
+var testAccMockServer *mockserver.Server
+
+func TestMain(m *testing.M) {
+	testAccMockServer = acctest.StartMockServer()
+	code := m.Run()
+	if testAccMockServer != nil {
+		testAccMockServer.Close()
+	}
+	os.Exit(code)
+}
+
 func testAccPreCheck(t *testing.T) {
 	requiredEnvs := []string{
 		"APOLLO_API_KEY",
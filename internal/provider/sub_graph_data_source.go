@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labd/terraform-provider-apollostudio/internal/utils"
+)
+
+var _ datasource.DataSource = &SubGraphDataSource{}
+
+func NewSubGraphDataSource() datasource.DataSource {
+	return &SubGraphDataSource{}
+}
+
+// SubGraphDataSource defines the data source implementation.
+type SubGraphDataSource struct {
+	clients *clientCache
+}
+
+// SubGraphDataSourceModel describes the data source data model.
+type SubGraphDataSourceModel struct {
+	ID        types.String   `tfsdk:"id"`
+	Name      types.String   `tfsdk:"name"`
+	GraphRef  types.String   `tfsdk:"graph_ref"`
+	URL       types.String   `tfsdk:"url"`
+	Schema    types.String   `tfsdk:"schema"`
+	Revision  types.String   `tfsdk:"revision"`
+	CreatedAt types.String   `tfsdk:"created_at"`
+	UpdatedAt types.String   `tfsdk:"updated_at"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (d *SubGraphDataSource) Metadata(
+	_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_sub_graph"
+}
+
+func (d *SubGraphDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source is used to read a sub graph published to a graph variant, " +
+			"for example to use the routing URL or schema of a sub graph managed by another team.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the sub graph",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the sub graph",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 64),
+				},
+			},
+			"graph_ref": schema.StringAttribute{
+				MarkdownDescription: "The graph ref the sub graph is published to, defaults to the provider graph ref",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					graphRefValidator,
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the sub graph endpoint",
+				Computed:            true,
+			},
+			"schema": schema.StringAttribute{
+				MarkdownDescription: "The SDL schema of the sub graph",
+				Computed:            true,
+			},
+			"revision": schema.StringAttribute{
+				MarkdownDescription: "The revision of the sub graph",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The creation date of the sub graph",
+				Computed:            true,
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "The last update date of the sub graph",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *SubGraphDataSource) Configure(
+	_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*clientCache)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *clientCache, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	d.clients = clients
+}
+
+func (d *SubGraphDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state SubGraphDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diagErr := state.Timeouts.Read(ctx, d.clients.timeout)
+	if diagErr.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	client, ref, err := d.clients.get(state.GraphRef.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("graph_ref"), "Client Error", err.Error())
+		return
+	}
+
+	name := state.Name.ValueString()
+	result, err := client.GetSubGraph(ctx, name)
+	utils.ProcessError(&resp.Diagnostics, err, "Operational errors when reading sub graph", "Client Error")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if result == nil || result.Name == "" {
+		resp.Diagnostics.AddError("Sub graph not found", fmt.Sprintf("Sub graph \"%s\" not found", name))
+		return
+	}
+
+	state.ID = types.StringValue(name)
+	state.GraphRef = types.StringValue(ref)
+	state.URL = urlValue(result.URL)
+	state.Schema = types.StringValue(result.ActivePartialSchema.Sdl)
+	state.Revision = types.StringValue(result.Revision)
+	state.CreatedAt = types.StringValue(result.CreatedAt.Format(time.RFC850))
+	state.UpdatedAt = types.StringValue(result.UpdatedAt.Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
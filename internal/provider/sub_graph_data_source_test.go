@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/labd/terraform-provider-apollostudio/internal/utils"
+)
+
+func TestAccSubGraphDataSource_basic(t *testing.T) {
+	schema := "type Query extend type Query { topCucumbers(first: Int = 5): [Cucumber] } type Cucumber @key(fields: id) { id: String! name1: String price: Int weight: Int }"
+	url := "https://example.com/graphql"
+	n := "data.apollostudio_sub_graph.cucumbers"
+
+	resource.Test(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			CheckDestroy:             testAccCheckSubGraphResourceDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccSubGraphDataSourceConfig(schema, url),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(n, "name", "cucumbers"),
+						resource.TestCheckResourceAttr(n, "url", url),
+						resource.TestCheckResourceAttr(n, "schema", schema),
+						resource.TestCheckResourceAttrPair(
+							n, "revision", "apollostudio_sub_graph.cucumbers", "revision",
+						),
+					),
+				},
+				{
+					Config: utils.HCLTemplate(
+						`
+						data "apollostudio_sub_graph" "missing" {
+						  name = "missing"
+						}
+						`,
+						nil,
+					),
+					ExpectError: regexp.MustCompile("Sub graph not found"),
+				},
+			},
+		},
+	)
+}
+
+func testAccSubGraphDataSourceConfig(schema, url string) string {
+	return utils.HCLTemplate(
+		`
+		resource "apollostudio_sub_graph" "cucumbers" {
+		  schema = "{{ .schema }}"
+		  name = "cucumbers"
+		  url = "{{ .url }}"
+		}
+
+		data "apollostudio_sub_graph" "cucumbers" {
+		  name = apollostudio_sub_graph.cucumbers.name
+		}
+		`,
+		map[string]any{
+			"schema": schema,
+			"url":    url,
+		},
+	)
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -13,7 +15,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/labd/apollostudio-go-sdk/apollostudio"
+	"github.com/labd/terraform-provider-apollostudio/internal/platform"
+	"github.com/labd/terraform-provider-apollostudio/internal/sdltypes"
 	"github.com/labd/terraform-provider-apollostudio/internal/utils"
 )
 
@@ -21,6 +24,7 @@ var (
 	_ resource.Resource                = &SubGraphResource{}
 	_ resource.ResourceWithConfigure   = &SubGraphResource{}
 	_ resource.ResourceWithImportState = &SubGraphResource{}
+	_ resource.ResourceWithModifyPlan  = &SubGraphResource{}
 )
 
 func NewSubGraphResource() resource.Resource {
@@ -29,19 +33,23 @@ func NewSubGraphResource() resource.Resource {
 
 // SubGraphResource defines the resource implementation.
 type SubGraphResource struct {
-	client *apollostudio.Client
+	clients *clientCache
 }
 
 // SubGraphResourceModel describes the resource data model.
 type SubGraphResourceModel struct {
-	URL       types.String   `tfsdk:"url"`
-	Schema    types.String   `tfsdk:"schema"`
-	Name      types.String   `tfsdk:"name"`
-	ID        types.String   `tfsdk:"id"`
-	Revision  types.String   `tfsdk:"revision"`
-	CreatedAt types.String   `tfsdk:"created_at"`
-	UpdatedAt types.String   `tfsdk:"updated_at"`
-	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+	URL       types.String        `tfsdk:"url"`
+	Schema    sdltypes.Normalized `tfsdk:"schema"`
+	Name      types.String        `tfsdk:"name"`
+	GraphRef  types.String        `tfsdk:"graph_ref"`
+	ID        types.String        `tfsdk:"id"`
+	Revision  types.String        `tfsdk:"revision"`
+	CreatedAt types.String        `tfsdk:"created_at"`
+	UpdatedAt types.String        `tfsdk:"updated_at"`
+	Timeouts  timeouts.Value      `tfsdk:"timeouts"`
+
+	FailOnCompositionError types.Bool `tfsdk:"fail_on_composition_error"`
+	FailOnBreakingChanges  types.Bool `tfsdk:"fail_on_breaking_changes"`
 }
 
 func (r *SubGraphResource) Metadata(
@@ -65,8 +73,13 @@ func (r *SubGraphResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"schema": schema.StringAttribute{
-				MarkdownDescription: "The SDL schema of the sub graph",
-				Required:            true,
+				MarkdownDescription: "The SDL schema of the sub graph. Changes that only affect whitespace, " +
+					"comments or the order of the definitions are ignored",
+				Required:   true,
+				CustomType: sdltypes.NormalizedType{},
+				PlanModifiers: []planmodifier.String{
+					sdltypes.UseStateForEquivalent(),
+				},
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "The name of the sub graph",
@@ -75,6 +88,43 @@ func (r *SubGraphResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 					stringvalidator.LengthBetween(1, 64),
 				},
 			},
+			"graph_ref": schema.StringAttribute{
+				MarkdownDescription: "The graph ref the sub graph is published to, defaults to the provider graph " +
+					"ref. Changing the graph ref, or the provider graph ref when it is not set, replaces the sub graph",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					graphRefValidator,
+				},
+				// a graph_ref that is not configured is planned as the provider
+				// graph ref in ModifyPlan
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(
+							_ context.Context, req planmodifier.StringRequest,
+							resp *stringplanmodifier.RequiresReplaceIfFuncResponse,
+						) {
+							// sub graphs created before graph_ref was tracked in state
+							// pick up the graph ref without being replaced
+							resp.RequiresReplace = !req.StateValue.IsNull() && !req.ConfigValue.IsNull() &&
+								!req.PlanValue.IsUnknown()
+						},
+						"Changing the graph ref publishes the sub graph to another graph variant.",
+						"Changing the graph ref publishes the sub graph to another graph variant.",
+					),
+				},
+			},
+			"fail_on_composition_error": schema.BoolAttribute{
+				MarkdownDescription: "Whether composition errors caused by publishing the sub graph fail the " +
+					"apply. By default composition errors are reported as warnings",
+				Optional: true,
+			},
+			"fail_on_breaking_changes": schema.BoolAttribute{
+				MarkdownDescription: "Whether a schema change that fails the schema checks blocks the plan. The " +
+					"schema is checked during `terraform plan` whenever it changes, by default failed checks are " +
+					"reported as warnings",
+				Optional: true,
+			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the sub graph",
 				Computed:            true,
@@ -105,13 +155,13 @@ func (r *SubGraphResource) Configure(
 		return
 	}
 
-	client, ok := req.ProviderData.(*apollostudio.Client)
+	clients, ok := req.ProviderData.(*clientCache)
 
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
+			"Unexpected Resource Configure Type",
 			fmt.Sprintf(
-				"Expected *apollostudio.Client, got: %T. Please report this issue to the provider developers.",
+				"Expected *clientCache, got: %T. Please report this issue to the provider developers.",
 				req.ProviderData,
 			),
 		)
@@ -119,7 +169,7 @@ func (r *SubGraphResource) Configure(
 		return
 	}
 
-	r.client = client
+	r.clients = clients
 }
 
 func (r *SubGraphResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -131,7 +181,7 @@ func (r *SubGraphResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	createTimeout, diagErr := plan.Timeouts.Create(ctx, defaultTimeout)
+	createTimeout, diagErr := plan.Timeouts.Create(ctx, r.clients.timeout)
 	if diagErr.HasError() {
 		return
 	}
@@ -139,31 +189,47 @@ func (r *SubGraphResource) Create(ctx context.Context, req resource.CreateReques
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
+	client, ref, err := r.clients.get(plan.GraphRef.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("graph_ref"), "Client Error", err.Error())
+		return
+	}
+
 	s := plan.Schema.ValueString()
 	name := plan.Name.ValueString()
-	url := plan.URL.ValueString()
 
-	graph, err := r.client.GetSubGraph(ctx, name)
+	graph, err := client.GetSubGraph(ctx, name)
 	utils.ProcessError(&resp.Diagnostics, err, "Operational errors when reading sub graph", "Client Error")
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	_, err = r.client.GetLatestSchemaBuild(ctx)
+	_, err = client.GetLatestSchemaBuild(ctx)
 	utils.ProcessError(&resp.Diagnostics, err, "Federation s contains errors", "Client Error")
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	result, err := r.client.SubmitSubGraph(
-		ctx, &apollostudio.SubmitOptions{
-			SubGraphSchema: []byte(s),
-			SubGraphName:   name,
-			SubGraphURL:    url,
+	result, err := r.clients.platform.PublishSubGraph(
+		ctx, &platform.PublishSubGraphOptions{
+			GraphRef: ref,
+			Name:     name,
+			URL:      plan.URL.ValueStringPointer(),
+			Schema:   s,
 		},
 	)
 
-	utils.ProcessError(&resp.Diagnostics, err, "Federation s error while submitting sub graph", "Client Error")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to publish sub graph, got error: %s", err))
+		return
+	}
+
+	if result == nil {
+		resp.Diagnostics.AddError("Client Error", "Unable to publish sub graph, got no result from Apollo Studio")
+		return
+	}
+
+	resp.Diagnostics.Append(compositionDiagnostics(result.Errors, plan.FailOnCompositionError.ValueBool())...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -179,6 +245,7 @@ func (r *SubGraphResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	plan.ID = types.StringValue(name)
+	plan.GraphRef = types.StringValue(ref)
 	plan.Revision = types.StringValue(graph.Revision)
 	plan.CreatedAt = types.StringValue(graph.CreatedAt.Format(time.RFC850))
 	plan.UpdatedAt = types.StringValue(graph.UpdatedAt.Format(time.RFC850))
@@ -194,7 +261,7 @@ func (r *SubGraphResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	readTimeout, diagErr := state.Timeouts.Read(ctx, defaultTimeout)
+	readTimeout, diagErr := state.Timeouts.Read(ctx, r.clients.timeout)
 	if diagErr.HasError() {
 		return
 	}
@@ -202,22 +269,43 @@ func (r *SubGraphResource) Read(ctx context.Context, req resource.ReadRequest, r
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
 	defer cancel()
 
+	client, ref, err := r.clients.get(state.GraphRef.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("graph_ref"), "Client Error", err.Error())
+		return
+	}
+
 	name := state.Name.ValueString()
-	result, err := r.client.GetSubGraph(ctx, name)
+	result, err := client.GetSubGraph(ctx, name)
 
 	utils.ProcessError(&resp.Diagnostics, err, "Operational errors when reading sub graph", "Client Error")
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if state.ID.IsNull() {
+	// the sub graph was removed outside of Terraform
+	if result == nil || result.Name == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// imported sub graphs only have a name
+	imported := state.ID.IsNull()
+	if imported {
 		state.ID = types.StringValue(name)
 	}
-	if state.URL.IsNull() && result.URL != "" {
-		state.URL = types.StringValue(result.URL)
+	state.GraphRef = types.StringValue(ref)
+
+	// read back the URL and schema, so changes published outside of Terraform,
+	// for example with rover, show up as drift in the next plan. The URL is
+	// only compared when it is configured or the sub graph is imported, a
+	// schema that only differs in formatting keeps the value in state.
+	if !state.URL.IsNull() || imported {
+		state.URL = urlValue(result.URL)
 	}
-	if state.Schema.IsNull() {
-		state.Schema = types.StringValue(result.ActivePartialSchema.Sdl)
+	sdl := result.ActivePartialSchema.Sdl
+	if state.Schema.IsNull() || !sdltypes.Equivalent(state.Schema.ValueString(), sdl) {
+		state.Schema = sdltypes.NewNormalizedValue(sdl)
 	}
 	state.Revision = types.StringValue(result.Revision)
 	state.CreatedAt = types.StringValue(result.CreatedAt.Format(time.RFC850))
@@ -230,6 +318,139 @@ func (r *SubGraphResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 }
 
+// urlValue returns the URL of a sub graph, sub graphs without a URL have an
+// empty one.
+func urlValue(url string) types.String {
+	if url == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(url)
+}
+
+// ModifyPlan runs the schema checks against the planned schema, so breaking
+// changes show up in the plan instead of at apply time.
+func (r *SubGraphResource) ModifyPlan(
+	ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse,
+) {
+	// nothing to check when the resource is destroyed or the provider is not
+	// configured yet
+	if req.Plan.Raw.IsNull() || r.clients == nil {
+		return
+	}
+
+	var plan SubGraphResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state *SubGraphResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// a graph_ref that is not configured follows the provider graph ref, so
+	// changing the provider graph ref replaces the sub graph just like
+	// changing its graph_ref does
+	var configRef types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("graph_ref"), &configRef)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if configRef.IsNull() && r.clients.graphRef != "" {
+		plan.GraphRef = types.StringValue(r.clients.graphRef)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("graph_ref"), plan.GraphRef)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state != nil && !state.GraphRef.IsNull() && !state.GraphRef.Equal(plan.GraphRef) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("graph_ref"))
+		}
+	}
+
+	if plan.Schema.IsUnknown() || plan.Name.IsUnknown() || configRef.IsUnknown() {
+		return
+	}
+
+	if state != nil && plan.Schema.Equal(state.Schema) && plan.Name.Equal(state.Name) {
+		return
+	}
+
+	readTimeout, diagErr := plan.Timeouts.Read(ctx, r.clients.timeout)
+	if diagErr.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ref, err := r.clients.resolve(plan.GraphRef.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("graph_ref"), "Client Error", err.Error())
+		return
+	}
+
+	name := plan.Name.ValueString()
+	result, err := r.clients.platform.CheckSubGraph(
+		ctx, &platform.CheckSubGraphOptions{
+			GraphRef: ref,
+			Name:     name,
+			Schema:   plan.Schema.ValueString(),
+		},
+	)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("schema"),
+			"Unable to check schema",
+			fmt.Sprintf("Unable to check the schema of \"%s\", got error: %s", name, err),
+		)
+		return
+	}
+
+	if len(result.Changes) > 0 {
+		changes := make([]string, len(result.Changes))
+		for i, c := range result.Changes {
+			changes[i] = fmt.Sprintf("[%s]: %s", c.Class(), c.Description)
+		}
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("schema"),
+			"Sub Graph changes detected",
+			fmt.Sprintf(
+				"%d changes detected on \"%s\" sub graph:\n\n%s",
+				len(changes), name, strings.Join(changes, "\n"),
+			),
+		)
+	}
+
+	// composition errors and breaking changes fail the check, they block the
+	// plan when fail_on_breaking_changes is set
+	fail := plan.FailOnBreakingChanges.ValueBool()
+	report := func(code, detail string) {
+		summary := "Sub Graph validation failed"
+		if code != "" {
+			summary = fmt.Sprintf("Sub Graph validation failed: %s", code)
+		}
+		if fail {
+			resp.Diagnostics.AddAttributeError(path.Root("schema"), summary, detail)
+		} else {
+			resp.Diagnostics.AddAttributeWarning(path.Root("schema"), summary, detail)
+		}
+	}
+	if result.CompositionFailed() {
+		for _, e := range result.CompositionCheckErrors() {
+			report(e.Code, e.Message)
+		}
+	}
+	for _, c := range result.Changes {
+		if c.Class() == platform.ChangeBreaking {
+			report(c.Code, c.Description)
+		}
+	}
+}
+
 func (r *SubGraphResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var plan SubGraphResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -245,7 +466,7 @@ func (r *SubGraphResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	updateTimeout, diagErr := plan.Timeouts.Update(ctx, defaultTimeout)
+	updateTimeout, diagErr := plan.Timeouts.Update(ctx, r.clients.timeout)
 	if diagErr.HasError() {
 		return
 	}
@@ -253,31 +474,41 @@ func (r *SubGraphResource) Update(ctx context.Context, req resource.UpdateReques
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
 	defer cancel()
 
+	client, ref, err := r.clients.get(plan.GraphRef.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("graph_ref"), "Client Error", err.Error())
+		return
+	}
+
 	s := plan.Schema.ValueString()
 	name := plan.Name.ValueString()
-	url := plan.URL.ValueString()
-
-	err := retry.RetryContext(
-		ctx, retryTimeout, func() *retry.RetryError {
-			var err error
-			_, err = r.client.SubmitSubGraph(
-				ctx, &apollostudio.SubmitOptions{
-					SubGraphSchema: []byte(s),
-					SubGraphName:   name,
-					SubGraphURL:    url,
-				},
-			)
-			return utils.NewRetryableError(err)
+
+	result, err := r.clients.platform.PublishSubGraph(
+		ctx, &platform.PublishSubGraphOptions{
+			GraphRef: ref,
+			Name:     name,
+			URL:      plan.URL.ValueStringPointer(),
+			Schema:   s,
 		},
 	)
 
-	utils.ProcessError(&resp.Diagnostics, err, "Federation s error while submitting sub graph", "Client Error")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to publish sub graph, got error: %s", err))
+		return
+	}
+
+	if result == nil {
+		resp.Diagnostics.AddError("Client Error", "Unable to publish sub graph, got no result from Apollo Studio")
+		return
+	}
+
+	resp.Diagnostics.Append(compositionDiagnostics(result.Errors, plan.FailOnCompositionError.ValueBool())...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	if !plan.Name.Equal(state.ID) {
-		err := r.client.RemoveSubGraph(ctx, state.Name.ValueString())
+		err := client.RemoveSubGraph(ctx, state.Name.ValueString())
 
 		utils.ProcessError(&resp.Diagnostics, err, "Operational errors when removing sub graph", "Client Error")
 		if resp.Diagnostics.HasError() {
@@ -285,7 +516,7 @@ func (r *SubGraphResource) Update(ctx context.Context, req resource.UpdateReques
 		}
 	}
 
-	rr, err := r.client.GetSubGraph(ctx, name)
+	rr, err := client.GetSubGraph(ctx, name)
 
 	utils.ProcessError(&resp.Diagnostics, err, "Operational errors when reading sub graph", "Client Error")
 	if resp.Diagnostics.HasError() {
@@ -297,6 +528,7 @@ func (r *SubGraphResource) Update(ctx context.Context, req resource.UpdateReques
 		plan.ID = plan.Name
 	}
 
+	plan.GraphRef = types.StringValue(ref)
 	plan.Revision = types.StringValue(rr.Revision)
 	plan.CreatedAt = types.StringValue(rr.CreatedAt.Format(time.RFC850))
 	plan.UpdatedAt = types.StringValue(rr.UpdatedAt.Format(time.RFC850))
@@ -316,7 +548,7 @@ func (r *SubGraphResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	deleteTimeout, diagErr := plan.Timeouts.Delete(ctx, defaultTimeout)
+	deleteTimeout, diagErr := plan.Timeouts.Delete(ctx, r.clients.timeout)
 	if diagErr.HasError() {
 		return
 	}
@@ -324,8 +556,14 @@ func (r *SubGraphResource) Delete(ctx context.Context, req resource.DeleteReques
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
 	defer cancel()
 
+	client, _, err := r.clients.get(plan.GraphRef.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("graph_ref"), "Client Error", err.Error())
+		return
+	}
+
 	name := plan.Name.ValueString()
-	err := r.client.RemoveSubGraph(ctx, name)
+	err = client.RemoveSubGraph(ctx, name)
 
 	utils.ProcessError(&resp.Diagnostics, err, "Operational errors when removing sub graph", "Client Error")
 	if resp.Diagnostics.HasError() {
@@ -336,5 +574,22 @@ func (r *SubGraphResource) Delete(ctx context.Context, req resource.DeleteReques
 func (r *SubGraphResource) ImportState(
 	ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse,
 ) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	// the import ID is either `<name>` or `<graph-ref>/<name>`
+	ref, name, found := strings.Cut(req.ID, "/")
+	if !found {
+		ref, name = "", req.ID
+	}
+
+	if name == "" || (found && ref == "") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <name> or <graph-ref>/<name>. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	if ref != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("graph_ref"), ref)...)
+	}
 }
@@ -3,12 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/labd/apollostudio-go-sdk/apollostudio"
 	"github.com/labd/terraform-provider-apollostudio/internal/acctest"
+	"github.com/labd/terraform-provider-apollostudio/internal/platform"
 	"github.com/labd/terraform-provider-apollostudio/internal/utils"
 )
 
@@ -63,6 +66,208 @@ func TestAccSubGraph_basic(t *testing.T) {
 	)
 }
 
+func TestAccSubGraph_semanticSchema(t *testing.T) {
+	schema := "type Query { radishes: [Radish] } type Radish @key(fields: id) { id: String! color: String }"
+	reformatted := "type Radish   @key(fields: id) {  id: String!  color: String  }   type Query { radishes: [Radish] }"
+	name := "radishes"
+	url := "https://example.com/graphql"
+	n := "apollostudio_sub_graph.radishes"
+
+	resource.Test(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			CheckDestroy:             testAccCheckSubGraphResourceDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccSubGraphConfig("radishes", schema, name, url),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(n, "schema", schema),
+					),
+				},
+				{
+					Config:   testAccSubGraphConfig("radishes", reformatted, name, url),
+					PlanOnly: true,
+				},
+			},
+		},
+	)
+}
+
+func TestAccSubGraph_drift(t *testing.T) {
+	schema := "type Query { beets: [Beet] } type Beet @key(fields: id) { id: String! color: String }"
+	published := "type Query { beets: [Beet] } type Beet @key(fields: id) { id: String! color: String weight: Int }"
+	name := "beets"
+	url := "https://example.com/graphql"
+
+	resource.Test(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			CheckDestroy:             testAccCheckSubGraphResourceDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccSubGraphConfig("beets", schema, name, url),
+				},
+				{
+					// publish the sub graph outside of Terraform, like rover does
+					PreConfig: func() {
+						otherURL := "https://example.com/other"
+						_, err := acctest.GetPlatformClient().PublishSubGraph(
+							context.Background(), &platform.PublishSubGraphOptions{
+								GraphRef: os.Getenv("APOLLO_GRAPH_REF"),
+								Name:     name,
+								URL:      &otherURL,
+								Schema:   published,
+							},
+						)
+						if err != nil {
+							t.Fatal(err)
+						}
+					},
+					Config:             testAccSubGraphConfig("beets", schema, name, url),
+					PlanOnly:           true,
+					ExpectNonEmptyPlan: true,
+				},
+				{
+					Config: testAccSubGraphConfig("beets", schema, name, url),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("apollostudio_sub_graph.beets", "schema", schema),
+						resource.TestCheckResourceAttr("apollostudio_sub_graph.beets", "url", url),
+					),
+				},
+			},
+		},
+	)
+}
+
+func TestAccSubGraph_disappears(t *testing.T) {
+	schema := "type Query { radishes: [Radish] } type Radish @key(fields: id) { id: String! color: String }"
+	name := "radishes"
+	url := "https://example.com/graphql"
+
+	resource.Test(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			CheckDestroy:             testAccCheckSubGraphResourceDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccSubGraphConfig("radishes", schema, name, url),
+				},
+				{
+					// remove the sub graph outside of Terraform
+					PreConfig: func() {
+						client, err := acctest.GetClient()
+						if err != nil {
+							t.Fatal(err)
+						}
+						if err := client.RemoveSubGraph(context.Background(), name); err != nil {
+							t.Fatal(err)
+						}
+					},
+					Config:             testAccSubGraphConfig("radishes", schema, name, url),
+					PlanOnly:           true,
+					ExpectNonEmptyPlan: true,
+				},
+				{
+					Config: testAccSubGraphConfig("radishes", schema, name, url),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("apollostudio_sub_graph.radishes", "name", name),
+					),
+				},
+			},
+		},
+	)
+}
+
+func TestAccSubGraph_graphRef(t *testing.T) {
+	schema := "type Query extend type Query { topCucumbers(first: Int = 5): [Cucumber] } type Cucumber @key(fields: id) { id: String! name1: String price: Int weight: Int }"
+	name := "vegetables"
+	url := "https://example.com/graphql"
+	ref := os.Getenv("APOLLO_GRAPH_REF")
+	n := "apollostudio_sub_graph.vegetables_sub_graph"
+
+	resource.Test(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			CheckDestroy:             testAccCheckSubGraphResourceDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccSubGraphConfigGraphRef("vegetables_sub_graph", schema, name, url, ref),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(n, "graph_ref", ref),
+						resource.TestCheckResourceAttr(n, "name", name),
+					),
+				},
+				{
+					ResourceName:            n,
+					ImportState:             true,
+					ImportStateId:           fmt.Sprintf("%s/%s", ref, name),
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"timeouts"},
+				},
+			},
+		},
+	)
+}
+
+func TestAccSubGraph_failOnCompositionError(t *testing.T) {
+	schema := "type Query extend type Query { topCucumbers(first: Int = 5): [Test] } type Cucumber @key(fields: id) { id: String! name1: String price: Int weight: Int }"
+
+	resource.Test(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			CheckDestroy:             testAccCheckSubGraphResourceDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: utils.HCLTemplate(
+						`
+						resource "apollostudio_sub_graph" "broken" {
+						  schema = "{{ .schema }}"
+						  name = "broken"
+						  url = "https://example.com/graphql"
+						  fail_on_composition_error = true
+						}
+						`,
+						map[string]any{
+							"schema": schema,
+						},
+					),
+					ExpectError: regexp.MustCompile("Composition error: INVALID_GRAPHQL"),
+				},
+			},
+		},
+	)
+}
+
+func TestAccSubGraph_planChecks(t *testing.T) {
+	schema := "type Query extend type Query { topCucumbers(first: Int = 5): [Cucumber] } type Cucumber @key(fields: id) { id: String! name1: String price: Int weight: Int }"
+	invalid := "type Query extend type Query { topCucumbers(first: Int = 5): [Test] } type Cucumber @key(fields: id) { id: String! name1: String price: Int weight: Int }"
+	name := "cucumbers"
+	url := "https://example.com/graphql"
+
+	resource.Test(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			CheckDestroy:             testAccCheckSubGraphResourceDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccSubGraphConfigFailOnBreakingChanges("cucumbers", schema, name, url),
+				},
+				{
+					Config:      testAccSubGraphConfigFailOnBreakingChanges("cucumbers", invalid, name, url),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile("Sub Graph validation failed"),
+				},
+			},
+		},
+	)
+}
+
 func testAccSubGraphConfig(res, schema, name, url string) string {
 	return utils.HCLTemplate(
 		`
@@ -97,6 +302,45 @@ func testAccSubGraphConfigNoURL(res, schema, name string) string {
 	)
 }
 
+func testAccSubGraphConfigGraphRef(res, schema, name, url, ref string) string {
+	return utils.HCLTemplate(
+		`
+		resource "apollostudio_sub_graph" {{ .res }} {
+		  schema = "{{ .schema }}"
+		  name = "{{ .name }}"
+		  url = "{{ .url }}"
+		  graph_ref = "{{ .ref }}"
+		}
+		`,
+		map[string]any{
+			"res":    res,
+			"schema": schema,
+			"name":   name,
+			"url":    url,
+			"ref":    ref,
+		},
+	)
+}
+
+func testAccSubGraphConfigFailOnBreakingChanges(res, schema, name, url string) string {
+	return utils.HCLTemplate(
+		`
+		resource "apollostudio_sub_graph" {{ .res }} {
+		  schema = "{{ .schema }}"
+		  name = "{{ .name }}"
+		  url = "{{ .url }}"
+		  fail_on_breaking_changes = true
+		}
+		`,
+		map[string]any{
+			"res":    res,
+			"schema": schema,
+			"name":   name,
+			"url":    url,
+		},
+	)
+}
+
 // testAccCheckSubGraphResourceDestroy verifies the Widget
 // has been destroyed
 func testAccCheckSubGraphResourceDestroy(s *terraform.State) error {
@@ -4,14 +4,28 @@ import (
 	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/labd/apollostudio-go-sdk/apollostudio"
+	"github.com/labd/terraform-provider-apollostudio/internal/platform"
 	"github.com/labd/terraform-provider-apollostudio/internal/utils"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
+)
+
+const (
+	failOnNever    = "never"
+	failOnBreaking = "breaking"
+	failOnAny      = "any"
+
+	lintFailOnError   = "error"
+	lintFailOnWarning = "warning"
 )
 
 var _ datasource.DataSource = &ValidationDataSource{}
@@ -22,7 +36,7 @@ func NewValidationDataSource() datasource.DataSource {
 
 // ValidationDataSource defines the data source implementation.
 type ValidationDataSource struct {
-	client *apollostudio.Client
+	clients *clientCache
 }
 
 // ValidationDataSourceModel describes the data source data model.
@@ -30,8 +44,63 @@ type ValidationDataSourceModel struct {
 	ID       types.String   `tfsdk:"id"`
 	Schema   types.String   `tfsdk:"schema"`
 	Name     types.String   `tfsdk:"name"`
-	Changes  types.String   `tfsdk:"changes"`
+	GraphRef types.String   `tfsdk:"graph_ref"`
+	Variant  types.String   `tfsdk:"variant"`
+	FailOn   types.String   `tfsdk:"fail_on"`
+	AllowNew types.Bool     `tfsdk:"allow_new"`
+	IsNew    types.Bool     `tfsdk:"is_new"`
+	Changes  []ChangeModel  `tfsdk:"changes"`
 	Timeouts timeouts.Value `tfsdk:"timeouts"`
+
+	CheckWindow        types.String   `tfsdk:"check_window"`
+	MinRequestCount    types.Int64    `tfsdk:"min_request_count"`
+	ExcludedClients    []types.String `tfsdk:"excluded_clients"`
+	ExcludedOperations []types.String `tfsdk:"excluded_operations"`
+
+	BreakingChangeCount types.Int64              `tfsdk:"breaking_change_count"`
+	AffectedOperations  []AffectedOperationModel `tfsdk:"affected_operations"`
+	AffectedClients     []AffectedClientModel    `tfsdk:"affected_clients"`
+	CheckWindowStart    types.String             `tfsdk:"check_window_start"`
+	CheckWindowEnd      types.String             `tfsdk:"check_window_end"`
+
+	WorkflowID types.String `tfsdk:"workflow_id"`
+	CheckURL   types.String `tfsdk:"check_url"`
+
+	LintFailLevel  types.String         `tfsdk:"lint_fail_level"`
+	LintViolations []LintViolationModel `tfsdk:"lint_violations"`
+
+	ChangesString types.String `tfsdk:"changes_string"`
+}
+
+// ChangeModel describes a single change detected by the schema checks.
+type ChangeModel struct {
+	Severity       types.String `tfsdk:"severity"`
+	Classification types.String `tfsdk:"classification"`
+	Code           types.String `tfsdk:"code"`
+	Coordinate     types.String `tfsdk:"coordinate"`
+	Description    types.String `tfsdk:"description"`
+}
+
+// LintViolationModel describes a violation of the linter rules of the graph.
+type LintViolationModel struct {
+	Rule       types.String `tfsdk:"rule"`
+	Level      types.String `tfsdk:"level"`
+	Coordinate types.String `tfsdk:"coordinate"`
+	Message    types.String `tfsdk:"message"`
+}
+
+// AffectedOperationModel describes an operation broken by the changes.
+type AffectedOperationModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	RequestCount types.Int64  `tfsdk:"request_count"`
+}
+
+// AffectedClientModel describes a client that sent operations broken by the
+// changes.
+type AffectedClientModel struct {
+	Name    types.String `tfsdk:"name"`
+	Version types.String `tfsdk:"version"`
 }
 
 func (d *ValidationDataSource) Metadata(
@@ -64,8 +133,194 @@ func (d *ValidationDataSource) Schema(ctx context.Context, _ datasource.SchemaRe
 					stringvalidator.LengthBetween(1, 64),
 				},
 			},
-			"changes": schema.StringAttribute{
-				MarkdownDescription: "The sub graph changes",
+			"graph_ref": schema.StringAttribute{
+				MarkdownDescription: "The graph ref to validate against, defaults to the provider graph ref",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					graphRefValidator,
+				},
+			},
+			"variant": schema.StringAttribute{
+				MarkdownDescription: "The variant to validate against instead of the variant of the graph ref, for " +
+					"example to check a schema against the traffic of `prod` while it is published to `staging`",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[a-zA-Z0-9_-]+$`),
+						"should only contain letters, digits, underscores and dashes",
+					),
+				},
+			},
+			"fail_on": schema.StringAttribute{
+				MarkdownDescription: "Which changes fail the validation, one of `never`, `breaking` or `any`. " +
+					"Composition errors always fail the validation, failed operation checks fail it unless this " +
+					"is set to `never`. Other changes are reported as warnings unless they match this setting. " +
+					"Defaults to `breaking`",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(failOnNever, failOnBreaking, failOnAny),
+				},
+			},
+			"allow_new": schema.BoolAttribute{
+				MarkdownDescription: "Whether a sub graph that is not published to the variant yet can be " +
+					"validated, the schema is then checked on whether adding the sub graph composes. " +
+					"Defaults to `false`",
+				Optional: true,
+			},
+			"is_new": schema.BoolAttribute{
+				MarkdownDescription: "Whether the sub graph is not published to the variant yet",
+				Computed:            true,
+			},
+			"check_window": schema.StringAttribute{
+				MarkdownDescription: "How far back the operation checks look for traffic, a duration such as " +
+					"`168h`. Defaults to the check configuration of the graph",
+				Optional: true,
+				Validators: []validator.String{
+					durationValidator,
+				},
+			},
+			"min_request_count": schema.Int64Attribute{
+				MarkdownDescription: "The minimum number of requests within the check window for an operation " +
+					"to be checked. Defaults to the check configuration of the graph",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"excluded_clients": schema.ListAttribute{
+				MarkdownDescription: "The names of the clients whose operations are not checked",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"excluded_operations": schema.ListAttribute{
+				MarkdownDescription: "The names of the operations that are not checked",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"changes": schema.ListNestedAttribute{
+				MarkdownDescription: "The changes detected on the sub graph",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"severity": schema.StringAttribute{
+							MarkdownDescription: "The severity of the change, `FAILURE` or `NOTICE`",
+							Computed:            true,
+						},
+						"classification": schema.StringAttribute{
+							MarkdownDescription: "The classification of the change, `breaking` when it breaks " +
+								"operations of the variant, `dangerous` when it removes or alters part of the " +
+								"schema without breaking operations and `safe` otherwise",
+							Computed: true,
+						},
+						"code": schema.StringAttribute{
+							MarkdownDescription: "The code of the change, for example `FIELD_REMOVED`",
+							Computed:            true,
+						},
+						"coordinate": schema.StringAttribute{
+							MarkdownDescription: "The schema coordinate of the changed type or field, for example `Query.products`",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "The description of the change",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"breaking_change_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of changes that break operations of the variant",
+				Computed:            true,
+			},
+			"affected_operations": schema.ListNestedAttribute{
+				MarkdownDescription: "The operations broken by the changes, ordered by name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the operation",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the operation",
+							Computed:            true,
+						},
+						"request_count": schema.Int64Attribute{
+							MarkdownDescription: "The number of requests of the operation within the check window",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"affected_clients": schema.ListNestedAttribute{
+				MarkdownDescription: "The clients that sent operations broken by the changes, ordered by name and version",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the client",
+							Computed:            true,
+						},
+						"version": schema.StringAttribute{
+							MarkdownDescription: "The version of the client",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"check_window_start": schema.StringAttribute{
+				MarkdownDescription: "The start of the traffic window the operations were checked against",
+				Computed:            true,
+			},
+			"check_window_end": schema.StringAttribute{
+				MarkdownDescription: "The end of the traffic window the operations were checked against",
+				Computed:            true,
+			},
+			"workflow_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the check workflow in Apollo Studio",
+				Computed:            true,
+			},
+			"check_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the check report in Apollo Studio",
+				Computed:            true,
+			},
+			"lint_fail_level": schema.StringAttribute{
+				MarkdownDescription: "The level at which lint violations fail the validation, one of `error`, " +
+					"`warning` or `never`. Violations below the level are reported as warnings. The schema is only linted " +
+					"when set, use `never` to report the violations without failing",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(lintFailOnError, lintFailOnWarning, failOnNever),
+				},
+			},
+			"lint_violations": schema.ListNestedAttribute{
+				MarkdownDescription: "The violations of the linter rules of the graph introduced by the schema, empty " +
+					"when `lint_fail_level` is not set",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"rule": schema.StringAttribute{
+							MarkdownDescription: "The linter rule, for example `FIELD_NAMES_SHOULD_BE_CAMEL_CASE`",
+							Computed:            true,
+						},
+						"level": schema.StringAttribute{
+							MarkdownDescription: "The level of the violation, `ERROR`, `WARNING` or `IGNORED`",
+							Computed:            true,
+						},
+						"coordinate": schema.StringAttribute{
+							MarkdownDescription: "The schema coordinate of the violation, for example `Query.products`",
+							Computed:            true,
+						},
+						"message": schema.StringAttribute{
+							MarkdownDescription: "The description of the violation",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"changes_string": schema.StringAttribute{
+				MarkdownDescription: "The sub graph changes as a comma separated string",
+				DeprecationMessage:  "Use the `changes` attribute instead, `changes_string` will be removed in a future release.",
 				Computed:            true,
 			},
 		},
@@ -83,13 +338,13 @@ func (d *ValidationDataSource) Configure(
 		return
 	}
 
-	client, ok := req.ProviderData.(*apollostudio.Client)
+	clients, ok := req.ProviderData.(*clientCache)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
 			fmt.Sprintf(
-				"Expected *apollostudio.Client, got: %T. Please report this issue to the provider developers.",
+				"Expected *clientCache, got: %T. Please report this issue to the provider developers.",
 				req.ProviderData,
 			),
 		)
@@ -97,7 +352,7 @@ func (d *ValidationDataSource) Configure(
 		return
 	}
 
-	d.client = client
+	d.clients = clients
 }
 
 func (d *ValidationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -112,7 +367,7 @@ func (d *ValidationDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	s := state.Schema.ValueString()
 	name := state.Name.ValueString()
 
-	readTimeout, diagErr := state.Timeouts.Read(ctx, defaultTimeout)
+	readTimeout, diagErr := state.Timeouts.Read(ctx, d.clients.timeout)
 	if diagErr.HasError() {
 		return
 	}
@@ -120,25 +375,59 @@ func (d *ValidationDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
 	defer cancel()
 
+	ref, err := d.clients.resolve(state.GraphRef.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("graph_ref"), "Client Error", err.Error())
+		return
+	}
+
+	// the checks run against the variant override, graph_ref keeps the graph
+	// ref the sub graph is published to
+	client, checkRef, err := d.clients.get(withVariant(ref, state.Variant.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("graph_ref"), "Client Error", err.Error())
+		return
+	}
+
 	// we are checking if schema with provided name already exists
-	// because validation may succeed even if provided schema does exist
-	graph, err := d.client.GetSubGraph(ctx, name)
+	// because validation may succeed even if provided schema does exist,
+	// new sub graphs are only checked when allow_new is set
+	graph, err := client.GetSubGraph(ctx, name)
 	utils.ProcessError(&resp.Diagnostics, err, "Operational errors when reading sub graph", "Client Error")
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if graph.Name == "" {
-		resp.Diagnostics.AddError("Sub graph not found", fmt.Sprintf("Sub graph \"%s\" not found", name))
+	state.IsNew = types.BoolValue(graph == nil || graph.Name == "")
+	if state.IsNew.ValueBool() && !state.AllowNew.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Sub graph not found",
+			fmt.Sprintf("Sub graph \"%s\" not found, set allow_new to validate a new sub graph", name),
+		)
 		return
 	}
 
-	result, err := d.client.ValidateSubGraph(
-		ctx, &apollostudio.ValidateOptions{
-			SubGraphSchema: []byte(s),
-			SubGraphName:   name,
-		},
-	)
+	opts := &platform.CheckSubGraphOptions{
+		GraphRef:            checkRef,
+		Name:                name,
+		Schema:              s,
+		QueryCountThreshold: int(state.MinRequestCount.ValueInt64()),
+	}
+	if !state.CheckWindow.IsNull() {
+		opts.Window, err = time.ParseDuration(state.CheckWindow.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("check_window"), "Invalid check window", err.Error())
+			return
+		}
+	}
+	for _, c := range state.ExcludedClients {
+		opts.ExcludedClients = append(opts.ExcludedClients, c.ValueString())
+	}
+	for _, o := range state.ExcludedOperations {
+		opts.ExcludedOperations = append(opts.ExcludedOperations, o.ValueString())
+	}
+
+	result, err := d.clients.platform.CheckSubGraph(ctx, opts)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -148,7 +437,15 @@ func (d *ValidationDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	if !result.IsValid() {
+	failOn := failOnBreaking
+	if !state.FailOn.IsNull() {
+		failOn = state.FailOn.ValueString()
+	}
+
+	// a failed check fails the validation, unless only the operation checks
+	// failed and fail_on is never, the changes are then reported as warnings
+	ignored := failOn == failOnNever && result.OperationsFailed() && !result.CompositionFailed()
+	if !result.IsValid() && !ignored {
 		errs := result.Errors()
 		if len(errs) == 0 {
 			resp.Diagnostics.AddError("Validation Error", "Unable to validate schema, but got no errors")
@@ -157,21 +454,155 @@ func (d *ValidationDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		for _, e := range errs {
 			resp.Diagnostics.AddError(fmt.Sprintf("Sub Graph validation failed: %s", e.Code), e.Message)
 		}
+		if result.TargetURL != "" {
+			resp.Diagnostics.AddError("Sub Graph validation failed", fmt.Sprintf("See %s", result.TargetURL))
+		}
 		return
 	}
 
-	if len(result.Changes()) > 0 {
-		state.Changes = types.StringValue(strings.Join(result.Changes(), ","))
-		resp.Diagnostics.AddWarning(
-			"Sub Graph changes detected",
-			fmt.Sprintf("%d changes detected on \"%s\" sub graph", len(result.Changes()), name),
+	state.WorkflowID = types.StringNull()
+	if result.WorkflowID != "" {
+		state.WorkflowID = types.StringValue(result.WorkflowID)
+	}
+	state.CheckURL = types.StringNull()
+	if result.TargetURL != "" {
+		state.CheckURL = types.StringValue(result.TargetURL)
+	}
+
+	breaking := result.BreakingChanges()
+	operations := map[string]platform.AffectedQuery{}
+	clients := map[string]AffectedClientModel{}
+
+	state.Changes = make([]ChangeModel, len(result.Changes))
+	changes := make([]string, len(result.Changes))
+	for i, c := range result.Changes {
+		state.Changes[i] = ChangeModel{
+			Severity:       types.StringValue(c.Severity),
+			Classification: types.StringValue(string(c.Class())),
+			Code:           types.StringValue(c.Code),
+			Coordinate:     types.StringValue(c.Coordinate()),
+			Description:    types.StringValue(c.Description),
+		}
+		changes[i] = c.String()
+		for _, q := range c.AffectedQueries {
+			operations[q.ID] = q
+		}
+		for _, client := range c.AffectedClients {
+			m := AffectedClientModel{
+				Name:    types.StringPointerValue(client.ClientName),
+				Version: types.StringPointerValue(client.ClientVersion),
+			}
+			clients[m.Name.ValueString()+"@"+m.Version.ValueString()] = m
+		}
+	}
+
+	state.BreakingChangeCount = types.Int64Value(int64(breaking))
+	state.AffectedOperations = make([]AffectedOperationModel, 0, len(operations))
+	for _, q := range operations {
+		state.AffectedOperations = append(state.AffectedOperations, AffectedOperationModel{
+			ID:           types.StringValue(q.ID),
+			Name:         types.StringPointerValue(q.Name),
+			RequestCount: types.Int64Value(q.RequestCount),
+		})
+	}
+	sort.Slice(state.AffectedOperations, func(i, j int) bool {
+		a, b := state.AffectedOperations[i], state.AffectedOperations[j]
+		if a.Name.ValueString() != b.Name.ValueString() {
+			return a.Name.ValueString() < b.Name.ValueString()
+		}
+		return a.ID.ValueString() < b.ID.ValueString()
+	})
+
+	state.AffectedClients = make([]AffectedClientModel, 0, len(clients))
+	for _, m := range clients {
+		state.AffectedClients = append(state.AffectedClients, m)
+	}
+	sort.Slice(state.AffectedClients, func(i, j int) bool {
+		a, b := state.AffectedClients[i], state.AffectedClients[j]
+		if a.Name.ValueString() != b.Name.ValueString() {
+			return a.Name.ValueString() < b.Name.ValueString()
+		}
+		return a.Version.ValueString() < b.Version.ValueString()
+	})
+
+	state.CheckWindowStart = types.StringNull()
+	state.CheckWindowEnd = types.StringNull()
+	if !result.WindowFrom.IsZero() {
+		state.CheckWindowStart = types.StringValue(result.WindowFrom.Format(time.RFC850))
+		state.CheckWindowEnd = types.StringValue(result.WindowTo.Format(time.RFC850))
+	}
+
+	state.ChangesString = types.StringNull()
+	if len(result.Changes) > 0 {
+		state.ChangesString = types.StringValue(strings.Join(changes, ","))
+
+		summary := fmt.Sprintf(
+			"%d changes detected on \"%s\" sub graph, %d of them breaking", len(result.Changes), name, breaking,
 		)
-		for _, c := range result.Changes() {
-			resp.Diagnostics.AddWarning(c, "")
+		if result.TargetURL != "" {
+			summary += fmt.Sprintf(", see %s", result.TargetURL)
+		}
+		if failOn == failOnAny || (failOn == failOnBreaking && breaking > 0) {
+			resp.Diagnostics.AddError("Sub Graph changes detected", summary)
+		} else {
+			resp.Diagnostics.AddWarning("Sub Graph changes detected", summary)
+		}
+
+		for _, c := range result.Changes {
+			detail := fmt.Sprintf(
+				"The change is %s and affects %d operations of %d clients",
+				c.Class(), len(c.AffectedQueries), len(c.AffectedClients),
+			)
+			if failOn == failOnAny || (failOn == failOnBreaking && c.Class() == platform.ChangeBreaking) {
+				resp.Diagnostics.AddError(c.Description, detail)
+			} else {
+				resp.Diagnostics.AddWarning(c.Description, detail)
+			}
+		}
+	}
+
+	// Without a lint_fail_level the violations are not wanted, so the schema
+	// is not linted at all.
+	var violations []platform.LintDiagnostic
+	lintFailLevel := state.LintFailLevel.ValueString()
+	if !state.LintFailLevel.IsNull() && !state.LintFailLevel.IsUnknown() {
+		var baseSDL *string
+		if !state.IsNew.ValueBool() {
+			baseSDL = &graph.ActivePartialSchema.Sdl
+		}
+		violations, err = d.clients.platform.LintSchema(ctx, checkRef, s, baseSDL)
+		if err != nil {
+			detail := fmt.Sprintf("Unable to lint schema, got error: %s", err)
+			if lintFailLevel != failOnNever {
+				resp.Diagnostics.AddError("Client Error", detail)
+				return
+			}
+			resp.Diagnostics.AddWarning("Client Error", detail)
+		}
+	}
+
+	state.LintViolations = make([]LintViolationModel, len(violations))
+	for i, v := range violations {
+		state.LintViolations[i] = LintViolationModel{
+			Rule:       types.StringValue(v.Rule),
+			Level:      types.StringValue(v.Level),
+			Coordinate: types.StringValue(v.Coordinate),
+			Message:    types.StringValue(v.Message),
+		}
+
+		summary := fmt.Sprintf("Lint violation: %s", v.Rule)
+		detail := fmt.Sprintf("%s (%s at %s)", v.Message, v.Level, v.Coordinate)
+		switch {
+		case v.Level == "ERROR" && (lintFailLevel == lintFailOnError || lintFailLevel == lintFailOnWarning),
+			v.Level == "WARNING" && lintFailLevel == lintFailOnWarning:
+			resp.Diagnostics.AddError(summary, detail)
+		case v.Level != "IGNORED":
+			resp.Diagnostics.AddWarning(summary, detail)
 		}
 	}
 
 	state.ID = types.StringValue(name)
+	state.GraphRef = types.StringValue(ref)
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -2,14 +2,18 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/labd/apollostudio-go-sdk/apollostudio"
 	"github.com/labd/terraform-provider-apollostudio/internal/acctest"
+	"github.com/labd/terraform-provider-apollostudio/internal/acctest/mockserver"
 	"github.com/labd/terraform-provider-apollostudio/internal/utils"
 )
 
@@ -40,6 +44,29 @@ func TestAccSubGraphValidation_ChangesAndErrors(t *testing.T) {
 					Config: testAccSubGraphValidateConfig(url, schema1, schema2, name),
 					Check: resource.ComposeTestCheckFunc(
 						testAccCheckSubGraphValidateChanges(n, name, schema2, false),
+						resource.TestCheckTypeSetElemNestedAttrs(
+							"data.apollostudio_sub_graph_validation.vegetables", "changes.*", map[string]string{
+								"code":       "FIELD_REMOVED",
+								"coordinate": "Cucumber.price",
+							},
+						),
+						resource.TestCheckTypeSetElemNestedAttrs(
+							"data.apollostudio_sub_graph_validation.vegetables", "changes.*", map[string]string{
+								"code":       "FIELD_ADDED",
+								"coordinate": "Cucumber.total",
+							},
+						),
+						resource.TestMatchResourceAttr(
+							"data.apollostudio_sub_graph_validation.vegetables", "changes_string",
+							regexp.MustCompile(`^\[[A-Z]+\]: `),
+						),
+						resource.TestCheckResourceAttrSet(
+							"data.apollostudio_sub_graph_validation.vegetables", "workflow_id",
+						),
+						resource.TestMatchResourceAttr(
+							"data.apollostudio_sub_graph_validation.vegetables", "check_url",
+							regexp.MustCompile(`^https://studio\.apollographql\.com/`),
+						),
 					),
 				},
 				{
@@ -51,6 +78,349 @@ func TestAccSubGraphValidation_ChangesAndErrors(t *testing.T) {
 	)
 }
 
+func TestAccSubGraphValidation_BreakingChanges(t *testing.T) {
+	if testAccMockServer == nil {
+		t.Skip("operation traffic can only be reported to the mock server")
+	}
+	testAccMockServer.AddOperation(os.Getenv("APOLLO_GRAPH_REF"), mockserver.Operation{
+		ID:            "tomato-prices",
+		Name:          "TomatoPrices",
+		ClientName:    "web",
+		ClientVersion: "1.2.0",
+		Fields:        []string{"Query.tomatoes", "Tomato.price"},
+		RequestCount:  42,
+	})
+
+	schema1 := "type Query { tomatoes: [Tomato] } type Tomato @key(fields: id) { id: String! price: Int }"
+	schema2 := "type Query { tomatoes: [Tomato] } type Tomato @key(fields: id) { id: String! weight: Int }"
+	name := "tomatoes"
+	url := "https://example.com/graphql"
+
+	d := "data.apollostudio_sub_graph_validation.tomatoes"
+
+	resource.Test(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			CheckDestroy:             testAccCheckSubGraphResourceDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccSubGraphValidateFailOnConfig(url, schema1, schema2, name, "never"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(d, "breaking_change_count", "1"),
+						resource.TestCheckTypeSetElemNestedAttrs(
+							d, "changes.*", map[string]string{
+								"coordinate":     "Tomato.price",
+								"severity":       "FAILURE",
+								"classification": "breaking",
+							},
+						),
+						resource.TestCheckTypeSetElemNestedAttrs(
+							d, "changes.*", map[string]string{
+								"coordinate":     "Tomato.weight",
+								"classification": "safe",
+							},
+						),
+						resource.TestCheckResourceAttr(d, "affected_operations.#", "1"),
+						resource.TestCheckResourceAttr(d, "affected_operations.0.id", "tomato-prices"),
+						resource.TestCheckResourceAttr(d, "affected_operations.0.name", "TomatoPrices"),
+						resource.TestCheckResourceAttr(d, "affected_operations.0.request_count", "42"),
+						resource.TestCheckResourceAttr(d, "affected_clients.#", "1"),
+						resource.TestCheckResourceAttr(d, "affected_clients.0.name", "web"),
+						resource.TestCheckResourceAttr(d, "affected_clients.0.version", "1.2.0"),
+						resource.TestCheckResourceAttrSet(d, "check_window_start"),
+						resource.TestCheckResourceAttrSet(d, "check_window_end"),
+					),
+				},
+				{
+					Config:      testAccSubGraphValidateFailOnConfig(url, schema1, schema2, name, "breaking"),
+					ExpectError: regexp.MustCompile("Sub Graph changes detected"),
+				},
+				{
+					Config: testAccSubGraphValidateExcludedConfig(url, schema1, schema2, name, "web"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(d, "breaking_change_count", "0"),
+						resource.TestCheckResourceAttr(d, "affected_operations.#", "0"),
+						resource.TestCheckTypeSetElemNestedAttrs(
+							d, "changes.*", map[string]string{
+								"coordinate":     "Tomato.price",
+								"classification": "dangerous",
+							},
+						),
+					),
+				},
+			},
+		},
+	)
+}
+
+func TestAccSubGraphValidation_Variant(t *testing.T) {
+	ref := os.Getenv("APOLLO_GRAPH_REF")
+	graphID, _, _ := strings.Cut(ref, "@")
+
+	schema1 := "type Query { peppers: [Pepper] } type Pepper @key(fields: id) { id: String! heat: Int }"
+	schema2 := "type Query { peppers: [Pepper] } type Pepper @key(fields: id) { id: String! color: String }"
+	name := "peppers"
+	url := "https://example.com/graphql"
+
+	d := "data.apollostudio_sub_graph_validation.peppers"
+
+	resource.Test(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			CheckDestroy:             testAccCheckSubGraphResourceDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccSubGraphValidateVariantConfig(url, schema1, schema2, name, graphID, "prod"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(d, "graph_ref", ref),
+						resource.TestCheckResourceAttr(d, "variant", "prod"),
+						resource.TestCheckTypeSetElemNestedAttrs(
+							d, "changes.*", map[string]string{
+								"code":       "FIELD_REMOVED",
+								"coordinate": "Pepper.heat",
+							},
+						),
+					),
+				},
+			},
+		},
+	)
+}
+
+func testAccSubGraphValidateVariantConfig(url, schema, newSchema, name, graphID, variant string) string {
+	return utils.HCLTemplate(
+		`
+		resource "apollostudio_sub_graph" "peppers" {
+		  schema = "{{ .schema }}"
+		  name = "{{ .name }}"
+		  url = "{{ .url }}"
+		  graph_ref = "{{ .graphID }}@{{ .variant }}"
+		}
+
+		data "apollostudio_sub_graph_validation" "peppers" {
+		  schema = "{{ .newSchema }}"
+		  name = apollostudio_sub_graph.peppers.name
+		  variant = "{{ .variant }}"
+		}
+		`,
+		map[string]any{
+			"schema":    schema,
+			"newSchema": newSchema,
+			"name":      name,
+			"url":       url,
+			"graphID":   graphID,
+			"variant":   variant,
+		},
+	)
+}
+
+func TestAccSubGraphValidation_New(t *testing.T) {
+	schema := "type Query { onions: [Onion] } type Onion @key(fields: id) { id: String! layers: Int }"
+	invalidSchema := "type Query { onions: [Shallot] }"
+	name := "onions"
+
+	d := "data.apollostudio_sub_graph_validation.onions"
+
+	resource.Test(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config:      testAccSubGraphValidateNewConfig(schema, name, false),
+					ExpectError: regexp.MustCompile("set allow_new to validate a new sub graph"),
+				},
+				{
+					Config: testAccSubGraphValidateNewConfig(schema, name, true),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(d, "is_new", "true"),
+						resource.TestCheckResourceAttr(d, "breaking_change_count", "0"),
+						resource.TestCheckTypeSetElemNestedAttrs(
+							d, "changes.*", map[string]string{
+								"code":           "TYPE_ADDED",
+								"coordinate":     "Onion",
+								"classification": "safe",
+							},
+						),
+					),
+				},
+				{
+					Config:      testAccSubGraphValidateNewConfig(invalidSchema, name, true),
+					ExpectError: regexp.MustCompile("INVALID_GRAPHQL"),
+				},
+			},
+		},
+	)
+}
+
+func testAccSubGraphValidateNewConfig(schema, name string, allowNew bool) string {
+	return utils.HCLTemplate(
+		`
+		data "apollostudio_sub_graph_validation" "onions" {
+		  schema = "{{ .schema }}"
+		  name = "{{ .name }}"
+		  allow_new = {{ .allowNew }}
+		}
+		`,
+		map[string]any{
+			"schema":   schema,
+			"name":     name,
+			"allowNew": allowNew,
+		},
+	)
+}
+
+func TestAccSubGraphValidation_Lint(t *testing.T) {
+	schema := "type Query { leeks: [Leek] } type Leek @key(fields: id) { id: String! leek_length: Int size: Int @deprecated }"
+	name := "leeks"
+
+	d := "data.apollostudio_sub_graph_validation.leeks"
+
+	resource.Test(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccSubGraphValidateLintConfig(schema, name, "never"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(d, "lint_violations.#", "2"),
+						resource.TestCheckTypeSetElemNestedAttrs(
+							d, "lint_violations.*", map[string]string{
+								"rule":       "FIELD_NAMES_SHOULD_BE_CAMEL_CASE",
+								"level":      "WARNING",
+								"coordinate": "Leek.leek_length",
+							},
+						),
+						resource.TestCheckTypeSetElemNestedAttrs(
+							d, "lint_violations.*", map[string]string{
+								"rule":       "DEPRECATED_DIRECTIVE_MISSING_REASON",
+								"level":      "ERROR",
+								"coordinate": "Leek.size",
+							},
+						),
+					),
+				},
+				{
+					Config:      testAccSubGraphValidateLintConfig(schema, name, "error"),
+					ExpectError: regexp.MustCompile("Lint violation: DEPRECATED_DIRECTIVE_MISSING_REASON"),
+				},
+			},
+		},
+	)
+}
+
+func TestAccSubGraphValidation_LintError(t *testing.T) {
+	if testAccMockServer == nil {
+		t.Skip("lint errors can only be simulated by the mock server")
+	}
+	testAccMockServer.SetLintError(errors.New("linter unavailable"))
+	t.Cleanup(func() { testAccMockServer.SetLintError(nil) })
+
+	schema := "type Query { leeks: [Leek] } type Leek @key(fields: id) { id: String! leek_length: Int size: Int @deprecated }"
+	name := "leeks"
+
+	d := "data.apollostudio_sub_graph_validation.leeks"
+
+	resource.Test(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccSubGraphValidateNewConfig(schema, name, true),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("data.apollostudio_sub_graph_validation.onions", "lint_violations.#", "0"),
+					),
+				},
+				{
+					Config: testAccSubGraphValidateLintConfig(schema, name, "never"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(d, "lint_violations.#", "0"),
+					),
+				},
+				{
+					Config:      testAccSubGraphValidateLintConfig(schema, name, "error"),
+					ExpectError: regexp.MustCompile("Unable to lint schema"),
+				},
+			},
+		},
+	)
+}
+
+func testAccSubGraphValidateLintConfig(schema, name, level string) string {
+	return utils.HCLTemplate(
+		`
+		data "apollostudio_sub_graph_validation" "leeks" {
+		  schema = "{{ .schema }}"
+		  name = "{{ .name }}"
+		  allow_new = true
+		  lint_fail_level = "{{ .level }}"
+		}
+		`,
+		map[string]any{
+			"schema": schema,
+			"name":   name,
+			"level":  level,
+		},
+	)
+}
+
+func testAccSubGraphValidateFailOnConfig(url, schema, newSchema, name, failOn string) string {
+	return utils.HCLTemplate(
+		`
+		resource "apollostudio_sub_graph" "tomatoes" {
+		  schema = "{{ .schema }}"
+		  name = "{{ .name }}"
+		  url = "{{ .url }}"
+		}
+
+		data "apollostudio_sub_graph_validation" "tomatoes" {
+		  schema = "{{ .newSchema }}"
+		  name = apollostudio_sub_graph.tomatoes.name
+		  fail_on = "{{ .failOn }}"
+		}
+		`,
+		map[string]any{
+			"schema":    schema,
+			"newSchema": newSchema,
+			"name":      name,
+			"url":       url,
+			"failOn":    failOn,
+		},
+	)
+}
+
+func testAccSubGraphValidateExcludedConfig(url, schema, newSchema, name, client string) string {
+	return utils.HCLTemplate(
+		`
+		resource "apollostudio_sub_graph" "tomatoes" {
+		  schema = "{{ .schema }}"
+		  name = "{{ .name }}"
+		  url = "{{ .url }}"
+		}
+
+		data "apollostudio_sub_graph_validation" "tomatoes" {
+		  schema = "{{ .newSchema }}"
+		  name = apollostudio_sub_graph.tomatoes.name
+		  fail_on = "breaking"
+		  check_window = "24h"
+		  min_request_count = 10
+		  excluded_clients = ["{{ .client }}"]
+		}
+		`,
+		map[string]any{
+			"schema":    schema,
+			"newSchema": newSchema,
+			"name":      name,
+			"url":       url,
+			"client":    client,
+		},
+	)
+}
+
 func testAccSubGraphValidateConfig(url, schema, newSchema, name string) string {
 	return utils.HCLTemplate(
 		`
@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &SubGraphsDataSource{}
+
+func NewSubGraphsDataSource() datasource.DataSource {
+	return &SubGraphsDataSource{}
+}
+
+// SubGraphsDataSource defines the data source implementation.
+type SubGraphsDataSource struct {
+	clients *clientCache
+}
+
+// SubGraphsDataSourceModel describes the data source data model.
+type SubGraphsDataSourceModel struct {
+	ID            types.String              `tfsdk:"id"`
+	GraphRef      types.String              `tfsdk:"graph_ref"`
+	IncludeSchema types.Bool                `tfsdk:"include_schema"`
+	SubGraphs     []SubGraphsDataSourceItem `tfsdk:"sub_graphs"`
+	Timeouts      timeouts.Value            `tfsdk:"timeouts"`
+}
+
+// SubGraphsDataSourceItem describes a single sub graph of the data source.
+type SubGraphsDataSourceItem struct {
+	Name      types.String `tfsdk:"name"`
+	URL       types.String `tfsdk:"url"`
+	Revision  types.String `tfsdk:"revision"`
+	Schema    types.String `tfsdk:"schema"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+}
+
+func (d *SubGraphsDataSource) Metadata(
+	_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_sub_graphs"
+}
+
+func (d *SubGraphsDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source lists all sub graphs published to a graph variant, including " +
+			"sub graphs that are not managed by Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The graph ref of the variant",
+				Computed:            true,
+			},
+			"graph_ref": schema.StringAttribute{
+				MarkdownDescription: "The graph ref to list the sub graphs of, defaults to the provider graph ref",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					graphRefValidator,
+				},
+			},
+			"include_schema": schema.BoolAttribute{
+				MarkdownDescription: "Whether to include the SDL schema of every sub graph, defaults to `false`",
+				Optional:            true,
+			},
+			"sub_graphs": schema.ListNestedAttribute{
+				MarkdownDescription: "The sub graphs published to the variant, ordered by name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the sub graph",
+							Computed:            true,
+						},
+						"url": schema.StringAttribute{
+							MarkdownDescription: "The URL of the sub graph endpoint",
+							Computed:            true,
+						},
+						"revision": schema.StringAttribute{
+							MarkdownDescription: "The revision of the sub graph",
+							Computed:            true,
+						},
+						"schema": schema.StringAttribute{
+							MarkdownDescription: "The SDL schema of the sub graph, only set when `include_schema` is enabled",
+							Computed:            true,
+						},
+						"created_at": schema.StringAttribute{
+							MarkdownDescription: "The creation date of the sub graph",
+							Computed:            true,
+						},
+						"updated_at": schema.StringAttribute{
+							MarkdownDescription: "The last update date of the sub graph",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *SubGraphsDataSource) Configure(
+	_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*clientCache)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *clientCache, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	d.clients = clients
+}
+
+func (d *SubGraphsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state SubGraphsDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diagErr := state.Timeouts.Read(ctx, d.clients.timeout)
+	if diagErr.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ref, err := d.clients.resolve(state.GraphRef.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("graph_ref"), "Client Error", err.Error())
+		return
+	}
+
+	includeSchema := state.IncludeSchema.ValueBool()
+	subGraphs, err := d.clients.platform.ListSubGraphs(ctx, ref, includeSchema)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list sub graphs, got error: %s", err))
+		return
+	}
+
+	sort.Slice(subGraphs, func(i, j int) bool { return subGraphs[i].Name < subGraphs[j].Name })
+
+	state.SubGraphs = make([]SubGraphsDataSourceItem, 0, len(subGraphs))
+	for _, sg := range subGraphs {
+		item := SubGraphsDataSourceItem{
+			Name:      types.StringValue(sg.Name),
+			URL:       types.StringNull(),
+			Revision:  types.StringValue(sg.Revision),
+			Schema:    types.StringNull(),
+			CreatedAt: types.StringValue(sg.CreatedAt.Format(time.RFC850)),
+			UpdatedAt: types.StringValue(sg.UpdatedAt.Format(time.RFC850)),
+		}
+		if sg.URL != nil && *sg.URL != "" {
+			item.URL = types.StringValue(*sg.URL)
+		}
+		if includeSchema {
+			item.Schema = types.StringValue(sg.ActivePartialSchema.Sdl)
+		}
+		state.SubGraphs = append(state.SubGraphs, item)
+	}
+
+	state.ID = types.StringValue(ref)
+	state.GraphRef = types.StringValue(ref)
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/labd/terraform-provider-apollostudio/internal/utils"
+)
+
+func TestAccSubGraphsDataSource_basic(t *testing.T) {
+	schema := "type Query extend type Query { topCucumbers(first: Int = 5): [Cucumber] } type Cucumber @key(fields: id) { id: String! name1: String price: Int weight: Int }"
+	url := "https://example.com/graphql"
+	n := "data.apollostudio_sub_graphs.all"
+
+	resource.Test(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			CheckDestroy:             testAccCheckSubGraphResourceDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccSubGraphsDataSourceConfig(schema, url, false),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckTypeSetElemNestedAttrs(
+							n, "sub_graphs.*", map[string]string{
+								"name": "cucumbers",
+								"url":  url,
+							},
+						),
+						resource.TestCheckTypeSetElemNestedAttrs(
+							n, "sub_graphs.*", map[string]string{
+								"name": "tomatoes",
+								"url":  url,
+							},
+						),
+						resource.TestCheckNoResourceAttr(n, "sub_graphs.0.schema"),
+					),
+				},
+				{
+					Config: testAccSubGraphsDataSourceConfig(schema, url, true),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckTypeSetElemNestedAttrs(
+							n, "sub_graphs.*", map[string]string{
+								"name":   "cucumbers",
+								"schema": schema,
+							},
+						),
+					),
+				},
+			},
+		},
+	)
+}
+
+func testAccSubGraphsDataSourceConfig(schema, url string, includeSchema bool) string {
+	return utils.HCLTemplate(
+		`
+		resource "apollostudio_sub_graph" "cucumbers" {
+		  schema = "{{ .schema }}"
+		  name = "cucumbers"
+		  url = "{{ .url }}"
+		}
+
+		resource "apollostudio_sub_graph" "tomatoes" {
+		  schema = "{{ .schema }}"
+		  name = "tomatoes"
+		  url = "{{ .url }}"
+		}
+
+		data "apollostudio_sub_graphs" "all" {
+		  include_schema = {{ .includeSchema }}
+
+		  depends_on = [
+		    apollostudio_sub_graph.cucumbers,
+		    apollostudio_sub_graph.tomatoes,
+		  ]
+		}
+		`,
+		map[string]any{
+			"schema":        schema,
+			"url":           url,
+			"includeSchema": includeSchema,
+		},
+	)
+}
@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &SupergraphDataSource{}
+
+func NewSupergraphDataSource() datasource.DataSource {
+	return &SupergraphDataSource{}
+}
+
+// SupergraphDataSource defines the data source implementation.
+type SupergraphDataSource struct {
+	clients *clientCache
+}
+
+// SupergraphDataSourceModel describes the data source data model.
+type SupergraphDataSourceModel struct {
+	ID        types.String   `tfsdk:"id"`
+	GraphRef  types.String   `tfsdk:"graph_ref"`
+	Schema    types.String   `tfsdk:"schema"`
+	Hash      types.String   `tfsdk:"hash"`
+	LaunchID  types.String   `tfsdk:"launch_id"`
+	SubGraphs []types.String `tfsdk:"sub_graphs"`
+	CreatedAt types.String   `tfsdk:"created_at"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (d *SupergraphDataSource) Metadata(
+	_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_supergraph"
+}
+
+func (d *SupergraphDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source returns the supergraph schema of the latest launch of a graph " +
+			"variant, so routers can be deployed with the exact supergraph instead of fetching it through " +
+			"uplink. More information about launches can be found " +
+			"[here](https://www.apollographql.com/docs/graphos/delivery/launches/).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the launch",
+				Computed:            true,
+			},
+			"graph_ref": schema.StringAttribute{
+				MarkdownDescription: "The graph ref to read the supergraph of, defaults to the provider graph ref",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					graphRefValidator,
+				},
+			},
+			"schema": schema.StringAttribute{
+				MarkdownDescription: "The composed supergraph SDL schema",
+				Computed:            true,
+			},
+			"hash": schema.StringAttribute{
+				MarkdownDescription: "The hash of the supergraph schema",
+				Computed:            true,
+			},
+			"launch_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the launch that composed the supergraph",
+				Computed:            true,
+			},
+			"sub_graphs": schema.ListAttribute{
+				MarkdownDescription: "The names of the sub graphs composed into the supergraph",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The creation date of the launch",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *SupergraphDataSource) Configure(
+	_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*clientCache)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *clientCache, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	d.clients = clients
+}
+
+func (d *SupergraphDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state SupergraphDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diagErr := state.Timeouts.Read(ctx, d.clients.timeout)
+	if diagErr.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ref, err := d.clients.resolve(state.GraphRef.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("graph_ref"), "Client Error", err.Error())
+		return
+	}
+
+	build, err := d.clients.platform.GetLatestSchemaBuild(ctx, ref)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read supergraph, got error: %s", err))
+		return
+	}
+
+	if build == nil {
+		resp.Diagnostics.AddError("Supergraph not found", fmt.Sprintf("No launches found for \"%s\"", ref))
+		return
+	}
+
+	if build.Pending {
+		resp.Diagnostics.AddError(
+			"Supergraph not built",
+			fmt.Sprintf("The latest launch %s of \"%s\" is still building (status %s), try again once it has completed", build.LaunchID, ref, build.Status),
+		)
+		return
+	}
+
+	if len(build.Errors) > 0 {
+		resp.Diagnostics.Append(compositionDiagnostics(build.Errors, true)...)
+		return
+	}
+
+	state.ID = types.StringValue(build.LaunchID)
+	state.GraphRef = types.StringValue(ref)
+	state.Schema = types.StringValue(build.SupergraphSDL)
+	state.Hash = types.StringValue(build.Hash)
+	state.LaunchID = types.StringValue(build.LaunchID)
+	state.CreatedAt = types.StringValue(build.CreatedAt.Format(time.RFC850))
+	state.SubGraphs = make([]types.String, len(build.SubGraphs))
+	for i, name := range build.SubGraphs {
+		state.SubGraphs[i] = types.StringValue(name)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/labd/terraform-provider-apollostudio/internal/utils"
+)
+
+func TestAccSupergraphDataSource_basic(t *testing.T) {
+	schema := "type Query extend type Query { topCucumbers(first: Int = 5): [Cucumber] } type Cucumber @key(fields: id) { id: String! name1: String price: Int weight: Int }"
+	url := "https://example.com/graphql"
+	n := "data.apollostudio_supergraph.current"
+
+	resource.Test(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			CheckDestroy:             testAccCheckSubGraphResourceDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccSupergraphDataSourceConfig(schema, url),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrSet(n, "launch_id"),
+						resource.TestCheckResourceAttrSet(n, "schema"),
+						resource.TestCheckResourceAttrSet(n, "hash"),
+						resource.TestCheckTypeSetElemAttr(n, "sub_graphs.*", "cucumbers"),
+					),
+				},
+			},
+		},
+	)
+}
+
+func TestAccSupergraphDataSource_pendingLaunch(t *testing.T) {
+	if testAccMockServer == nil {
+		t.Skip("pending launches can only be created on the mock server")
+	}
+
+	schema := "type Query extend type Query { topCucumbers(first: Int = 5): [Cucumber] } type Cucumber @key(fields: id) { id: String! name1: String price: Int weight: Int }"
+	url := "https://example.com/graphql"
+
+	resource.Test(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			CheckDestroy:             testAccCheckSubGraphResourceDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccSupergraphDataSourceConfig(schema, url),
+				},
+				{
+					PreConfig: func() {
+						testAccMockServer.AddPendingLaunch(os.Getenv("APOLLO_GRAPH_REF"))
+					},
+					Config:      testAccSupergraphDataSourceConfig(schema, url),
+					ExpectError: regexp.MustCompile("Supergraph not built"),
+				},
+				{
+					// Drop the data source so the post-test destroy does not
+					// read the pending launch.
+					Config: testAccSubGraphConfig("cucumbers", schema, "cucumbers", url),
+				},
+			},
+		},
+	)
+}
+
+func testAccSupergraphDataSourceConfig(schema, url string) string {
+	return utils.HCLTemplate(
+		`
+		resource "apollostudio_sub_graph" "cucumbers" {
+		  schema = "{{ .schema }}"
+		  name = "cucumbers"
+		  url = "{{ .url }}"
+		}
+
+		data "apollostudio_supergraph" "current" {
+		  depends_on = [apollostudio_sub_graph.cucumbers]
+		}
+		`,
+		map[string]any{
+			"schema": schema,
+			"url":    url,
+		},
+	)
+}
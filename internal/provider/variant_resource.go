@@ -0,0 +1,341 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labd/terraform-provider-apollostudio/internal/platform"
+)
+
+var (
+	_ resource.Resource                = &VariantResource{}
+	_ resource.ResourceWithConfigure   = &VariantResource{}
+	_ resource.ResourceWithImportState = &VariantResource{}
+)
+
+func NewVariantResource() resource.Resource {
+	return &VariantResource{}
+}
+
+// VariantResource defines the resource implementation.
+type VariantResource struct {
+	client  *platform.Client
+	timeout time.Duration
+}
+
+// VariantResourceModel describes the resource data model.
+type VariantResourceModel struct {
+	ID             types.String   `tfsdk:"id"`
+	GraphID        types.String   `tfsdk:"graph_id"`
+	Name           types.String   `tfsdk:"name"`
+	URL            types.String   `tfsdk:"url"`
+	IsPublic       types.Bool     `tfsdk:"is_public"`
+	DefaultHeaders types.Map      `tfsdk:"default_headers"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *VariantResource) Metadata(
+	_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_variant"
+}
+
+func (r *VariantResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource is used to manage variants of a graph in Apollo Studio. " +
+			"More information about variants can be found " +
+			"[here](https://www.apollographql.com/docs/graphos/graphs/#variants).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The graph ref of the variant, in the format of `<graph-name>@<variant-name>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"graph_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the graph the variant belongs to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the variant, for example `main` or `staging`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[a-zA-Z0-9_-]+$`),
+						"should only contain letters, numbers, underscores and dashes",
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The routing URL of the variant, used by Explorer to send operations to",
+				Optional:            true,
+			},
+			"is_public": schema.BoolAttribute{
+				MarkdownDescription: "Whether the variant is publicly accessible. Defaults to `false`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"default_headers": schema.MapAttribute{
+				MarkdownDescription: "The default headers Explorer sends with operations on the variant",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *VariantResource) Configure(
+	_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*clientCache)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *clientCache, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = clients.platform
+	r.timeout = clients.timeout
+}
+
+func (r *VariantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan VariantResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diagErr := plan.Timeouts.Create(ctx, r.timeout)
+	if diagErr.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	opts, diags := plan.updateOptions(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.CreateVariant(ctx, opts.GraphID, opts.Name)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create variant, got error: %s", err))
+		return
+	}
+
+	// save the variant before configuring it, so a failed update taints the
+	// variant instead of leaving it behind outside of the state
+	plan.ID = types.StringValue(opts.GraphID + "@" + opts.Name)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err = r.client.UpdateVariant(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to configure variant, got error: %s", err))
+		return
+	}
+}
+
+func (r *VariantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state VariantResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diagErr := state.Timeouts.Read(ctx, r.timeout)
+	if diagErr.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ref := state.GraphID.ValueString() + "@" + state.Name.ValueString()
+	variant, err := r.client.GetVariant(ctx, ref)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read variant, got error: %s", err))
+		return
+	}
+
+	if variant == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ID = types.StringValue(ref)
+	state.URL = types.StringPointerValue(variant.URL)
+	state.IsPublic = types.BoolValue(variant.IsPublic)
+
+	state.DefaultHeaders = types.MapNull(types.StringType)
+	if variant.DefaultHeaders != nil && *variant.DefaultHeaders != "" {
+		var headers map[string]string
+		if err := json.Unmarshal([]byte(*variant.DefaultHeaders), &headers); err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to parse default headers of variant, got error: %s", err),
+			)
+			return
+		}
+
+		if len(headers) > 0 {
+			state.DefaultHeaders, diags = types.MapValueFrom(ctx, types.StringType, headers)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *VariantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan VariantResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diagErr := plan.Timeouts.Update(ctx, r.timeout)
+	if diagErr.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	opts, diags := plan.updateOptions(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateVariant(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update variant, got error: %s", err))
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *VariantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state VariantResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diagErr := state.Timeouts.Delete(ctx, r.timeout)
+	if diagErr.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteVariant(ctx, state.GraphID.ValueString(), state.Name.ValueString())
+	if err != nil && !errors.Is(err, platform.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete variant, got error: %s", err))
+		return
+	}
+}
+
+func (r *VariantResource) ImportState(
+	ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse,
+) {
+	if !graphRefRegexp.MatchString(req.ID) {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <graph-name>@<variant-name>. Got: %q", req.ID),
+		)
+		return
+	}
+
+	graphID, name, _ := strings.Cut(req.ID, "@")
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("graph_id"), graphID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+func (m *VariantResourceModel) updateOptions(ctx context.Context) (*platform.UpdateVariantOptions, diag.Diagnostics) {
+	opts := &platform.UpdateVariantOptions{
+		GraphID:  m.GraphID.ValueString(),
+		Name:     m.Name.ValueString(),
+		URL:      m.URL.ValueStringPointer(),
+		IsPublic: m.IsPublic.ValueBool(),
+	}
+
+	if m.DefaultHeaders.IsNull() {
+		return opts, nil
+	}
+
+	headers := map[string]string{}
+	diags := m.DefaultHeaders.ElementsAs(ctx, &headers, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	data, err := json.Marshal(headers)
+	if err != nil {
+		diags.AddError("Invalid default headers", err.Error())
+		return nil, diags
+	}
+
+	defaultHeaders := string(data)
+	opts.DefaultHeaders = &defaultHeaders
+	return opts, diags
+}
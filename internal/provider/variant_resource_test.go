@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/labd/terraform-provider-apollostudio/internal/acctest"
+	"github.com/labd/terraform-provider-apollostudio/internal/utils"
+)
+
+func TestAccVariant_basic(t *testing.T) {
+	graphID, _, _ := strings.Cut(os.Getenv("APOLLO_GRAPH_REF"), "@")
+	name := "terraform-acc"
+	n := "apollostudio_variant.test"
+
+	resource.Test(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			CheckDestroy:             testAccCheckVariantResourceDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccVariantConfig("test", graphID, name, "https://example.com/graphql", false),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(n, "id", graphID+"@"+name),
+						resource.TestCheckResourceAttr(n, "name", name),
+						resource.TestCheckResourceAttr(n, "url", "https://example.com/graphql"),
+						resource.TestCheckResourceAttr(n, "is_public", "false"),
+						resource.TestCheckNoResourceAttr(n, "default_headers.%"),
+					),
+				},
+				{
+					Config: testAccVariantConfig("test", graphID, name, "https://example.com/v2/graphql", true),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(n, "url", "https://example.com/v2/graphql"),
+						resource.TestCheckResourceAttr(n, "is_public", "true"),
+						resource.TestCheckResourceAttr(n, "default_headers.%", "1"),
+						resource.TestCheckResourceAttr(n, "default_headers.x-client", "terraform"),
+					),
+				},
+				{
+					ResourceName:            n,
+					ImportState:             true,
+					ImportStateId:           graphID + "@" + name,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"timeouts"},
+				},
+			},
+		},
+	)
+}
+
+func testAccVariantConfig(res, graphID, name, url string, public bool) string {
+	return utils.HCLTemplate(
+		`
+		resource "apollostudio_variant" {{ .res }} {
+		  graph_id = "{{ .graphID }}"
+		  name = "{{ .name }}"
+		  url = "{{ .url }}"
+		  {{ if .public }}
+		  is_public = true
+		  default_headers = {
+		    x-client = "terraform"
+		  }
+		  {{ end }}
+		}
+		`,
+		map[string]any{
+			"res":     res,
+			"graphID": graphID,
+			"name":    name,
+			"url":     url,
+			"public":  public,
+		},
+	)
+}
+
+func testAccCheckVariantResourceDestroy(s *terraform.State) error {
+	client := acctest.GetPlatformClient()
+	ctx := context.Background()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "apollostudio_variant" {
+			continue
+		}
+		variant, err := client.GetVariant(ctx, rs.Primary.ID)
+		if err == nil && variant != nil {
+			return fmt.Errorf("variant (%s) still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+// Package sdltypes implements a Terraform string type for GraphQL SDL schemas
+// that compares schemas semantically instead of as raw strings.
+package sdltypes
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// Normalize parses the SDL and formats it in a canonical form. Whitespace,
+// comments and the order of the definitions do not affect the result, the
+// order of fields and arguments within a definition does.
+func Normalize(sdl string) (string, error) {
+	doc, err := parser.ParseSchema(&ast.Source{Input: sdl})
+	if err != nil {
+		return "", err
+	}
+
+	sort.SliceStable(doc.Directives, func(i, j int) bool {
+		return doc.Directives[i].Name < doc.Directives[j].Name
+	})
+	sortDefinitions(doc.Definitions)
+	sortDefinitions(doc.Extensions)
+
+	var out strings.Builder
+	formatter.NewFormatter(&out).FormatSchemaDocument(doc)
+	return out.String(), nil
+}
+
+// Equivalent reports whether both SDLs normalize to the same schema. SDL
+// that cannot be parsed is only equivalent when it is identical.
+func Equivalent(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	normalizedA, err := Normalize(a)
+	if err != nil {
+		return false
+	}
+	normalizedB, err := Normalize(b)
+	if err != nil {
+		return false
+	}
+	return normalizedA == normalizedB
+}
+
+func sortDefinitions(definitions ast.DefinitionList) {
+	sort.SliceStable(definitions, func(i, j int) bool {
+		if definitions[i].Kind != definitions[j].Kind {
+			return definitions[i].Kind < definitions[j].Kind
+		}
+		return definitions[i].Name < definitions[j].Name
+	})
+}
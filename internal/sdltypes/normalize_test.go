@@ -0,0 +1,52 @@
+package sdltypes
+
+import "testing"
+
+func TestEquivalent(t *testing.T) {
+	schema := "type Query { products: [Product] } type Product { id: ID! name: String }"
+
+	tests := []struct {
+		name  string
+		other string
+		want  bool
+	}{
+		{
+			name:  "whitespace",
+			other: "type Query {\n  products: [Product]\n}\n\ntype Product {\n  id: ID!\n  name: String\n}\n",
+			want:  true,
+		},
+		{
+			name:  "comments",
+			other: "# the root query\ntype Query { products: [Product] } type Product { id: ID! name: String }",
+			want:  true,
+		},
+		{
+			name:  "definition order",
+			other: "type Product { id: ID! name: String } type Query { products: [Product] }",
+			want:  true,
+		},
+		{
+			name:  "field added",
+			other: "type Query { products: [Product] } type Product { id: ID! name: String price: Int }",
+			want:  false,
+		},
+		{
+			name:  "description added",
+			other: "type Query { products: [Product] } \"A product\" type Product { id: ID! name: String }",
+			want:  false,
+		},
+		{
+			name:  "invalid",
+			other: "type Query {",
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Equivalent(schema, tt.other); got != tt.want {
+				t.Errorf("Equivalent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
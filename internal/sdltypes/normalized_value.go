@@ -0,0 +1,62 @@
+package sdltypes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ basetypes.StringValuableWithSemanticEquals = Normalized{}
+
+// Normalized is a GraphQL SDL value. Values that only differ in whitespace,
+// comments or the order of their definitions are semantically equal.
+type Normalized struct {
+	basetypes.StringValue
+}
+
+// NewNormalizedNull creates a null SDL value.
+func NewNormalizedNull() Normalized {
+	return Normalized{StringValue: basetypes.NewStringNull()}
+}
+
+// NewNormalizedValue creates a known SDL value.
+func NewNormalizedValue(value string) Normalized {
+	return Normalized{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v Normalized) Type(_ context.Context) attr.Type {
+	return NormalizedType{}
+}
+
+func (v Normalized) Equal(o attr.Value) bool {
+	other, ok := o.(Normalized)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether both values describe the same schema.
+func (v Normalized) StringSemanticEquals(
+	_ context.Context, newValuable basetypes.StringValuable,
+) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(Normalized)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf(
+				"An unexpected value type was received while performing semantic equality checks. "+
+					"Please report this to the provider developers.\n\nExpected Value Type: %T\nGot Value Type: %T",
+				v, newValuable,
+			),
+		)
+		return false, diags
+	}
+
+	return Equivalent(v.ValueString(), newValue.ValueString()), diags
+}
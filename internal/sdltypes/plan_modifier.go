@@ -0,0 +1,37 @@
+package sdltypes
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+)
+
+// UseStateForEquivalent returns a plan modifier that keeps the SDL in state
+// when the configured SDL is semantically equal to it. Semantic equality is
+// only applied to values read from the API, this modifier prevents a plan for
+// configuration changes that do not change the schema, such as reformatting.
+func UseStateForEquivalent() planmodifier.String {
+	return useStateForEquivalentModifier{}
+}
+
+type useStateForEquivalentModifier struct{}
+
+func (m useStateForEquivalentModifier) Description(_ context.Context) string {
+	return "Keeps the schema in state when the configured schema only differs in formatting, comments or order."
+}
+
+func (m useStateForEquivalentModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateForEquivalentModifier) PlanModifyString(
+	_ context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse,
+) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	if Equivalent(req.StateValue.ValueString(), req.PlanValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket that allows a number of requests per second.
+// The bucket holds at most one second of tokens, so idle time allows a short
+// burst before requests are spread out evenly.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func NewRateLimiter(requestsPerSecond int) *RateLimiter {
+	rate := float64(requestsPerSecond)
+	return &RateLimiter{
+		rate:   rate,
+		burst:  rate,
+		tokens: rate,
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request is allowed or the context is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	// take the token up front, a negative balance queues the request behind
+	// the requests that are already waiting
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		// return the token, the request is not sent
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rateLimitedTransport waits for the rate limiter before every request, so
+// all clients sharing the transport stay below the configured rate.
+type rateLimitedTransport struct {
+	limiter *RateLimiter
+	base    http.RoundTripper
+}
+
+func NewRateLimitedTransport(limiter *RateLimiter, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &rateLimitedTransport{limiter: limiter, base: base}
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
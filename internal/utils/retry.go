@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy retries requests with an exponential backoff. The backoff
+// doubles with every attempt, starting at MinBackoff, and is capped at
+// MaxBackoff. A random jitter spreads the retries of parallel operations, so
+// they do not hit the API at the same moment.
+type RetryPolicy struct {
+	MaxRetries int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// Backoff returns the time to wait before the given retry, starting at 0. The
+// result is between half and the full exponential backoff for the attempt.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := p.MinBackoff
+	for i := 0; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+
+	half := backoff / 2
+	return half + rand.N(backoff-half+1)
+}
+
+// HTTPBackoff is a retryablehttp.Backoff that waits as long as the Retry-After
+// header of a rate limited (429) or unavailable (503) response asks for, and
+// uses the backoff of the policy otherwise. The Retry-After wait is capped at
+// MaxBackoff and at the deadline of the request. The min and max arguments are
+// ignored in favor of the policy.
+func (p RetryPolicy) HTTPBackoff(_, _ time.Duration, attempt int, resp *http.Response) time.Duration {
+	if resp != nil &&
+		(resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			if wait > p.MaxBackoff {
+				wait = p.MaxBackoff
+			}
+			if resp.Request != nil {
+				if deadline, ok := resp.Request.Context().Deadline(); ok && time.Until(deadline) < wait {
+					wait = max(time.Until(deadline), 0)
+				}
+			}
+			return wait
+		}
+	}
+	return p.Backoff(attempt)
+}
+
+// retryAfter parses a Retry-After header, which is either a number of seconds
+// or an HTTP date.
+func retryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(header, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(header)
+	if err != nil {
+		return 0, false
+	}
+	if wait := date.Sub(now); wait > 0 {
+		return wait, true
+	}
+	return 0, true
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{attempt: 0, max: time.Second},
+		{attempt: 1, max: 2 * time.Second},
+		{attempt: 3, max: 8 * time.Second},
+		{attempt: 4, max: 10 * time.Second},
+		{attempt: 100, max: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 50; i++ {
+			got := p.Backoff(tt.attempt)
+			if got < tt.max/2 || got > tt.max {
+				t.Fatalf("Backoff(%d) = %s, want between %s and %s", tt.attempt, got, tt.max/2, tt.max)
+			}
+		}
+	}
+}
+
+func TestRetryPolicyHTTPBackoff(t *testing.T) {
+	p := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	tests := []struct {
+		name   string
+		status int
+		header string
+		min    time.Duration
+		max    time.Duration
+	}{
+		{name: "too many requests", status: http.StatusTooManyRequests, header: "5", min: 5 * time.Second, max: 5 * time.Second},
+		{name: "unavailable", status: http.StatusServiceUnavailable, header: "3", min: 3 * time.Second, max: 3 * time.Second},
+		{name: "capped at max backoff", status: http.StatusTooManyRequests, header: "120", min: 10 * time.Second, max: 10 * time.Second},
+		{name: "date in the past", status: http.StatusTooManyRequests, header: "Fri, 31 Dec 1999 23:59:59 GMT", min: 0, max: 0},
+		{name: "invalid header", status: http.StatusTooManyRequests, header: "soon", min: time.Second / 2, max: time.Second},
+		{name: "server error", status: http.StatusInternalServerError, header: "120", min: time.Second / 2, max: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: http.Header{"Retry-After": []string{tt.header}}}
+			got := p.HTTPBackoff(0, 0, 0, resp)
+			if got < tt.min || got > tt.max {
+				t.Errorf("HTTPBackoff() = %s, want between %s and %s", got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyHTTPBackoffDeadline(t *testing.T) {
+	p := RetryPolicy{MinBackoff: time.Second, MaxBackoff: time.Minute}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://example.com/graphql", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"30"}},
+		Request:    req,
+	}
+
+	if got := p.HTTPBackoff(0, 0, 0, resp); got > 2*time.Second {
+		t.Errorf("HTTPBackoff() = %s, want at most the 2s until the deadline", got)
+	}
+}